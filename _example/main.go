@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	"github.com/Kansuler/configura"
 	"github.com/Kansuler/configura/_example/config"
@@ -16,7 +17,7 @@ func main() {
 	os.Setenv(string(config.API_KEY), "supersecretapikey")
 	os.Setenv(string(config.ENABLE_FEATURE_X), "true")
 	os.Setenv(string(subpackage.SUBPACKAGE_DEFINED_CONFIG), "some_value")
-	// TIMEOUT_SECONDS is not set, so its fallback will be used.
+	// TIMEOUT is not set, so its fallback will be used.
 
 	// --- Initialize Configura ---
 	cfg := configura.New()
@@ -26,11 +27,11 @@ func main() {
 	configura.Load(cfg, config.PORT, 3000)  // Fallback port 3000
 	configura.Load(cfg, config.API_KEY, "") // Fallback empty string if not set
 	configura.Load(cfg, config.ENABLE_FEATURE_X, false)
-	configura.Load(cfg, config.TIMEOUT_SECONDS, int64(30)) // Fallback 30 seconds
+	configura.Load(cfg, config.TIMEOUT, 30*time.Second) // Fallback 30 seconds
 	configura.Load(cfg, subpackage.SUBPACKAGE_DEFINED_CONFIG, "default_value")
 
 	// Set the configuration by yourself
-	configura.Write(cfg, map[configura.Variable[int64]]int64{config.TIMEOUT_SECONDS: 25})
+	configura.Write(cfg, map[configura.Variable[time.Duration]]time.Duration{config.TIMEOUT: 25 * time.Second})
 
 	err := subpackage.Initialize(cfg)
 	if err != nil {
@@ -20,7 +20,7 @@ var RequiredUserServiceKeys = []any{
 
 // Initialize sets up the user service with the given configuration.
 // It validates that all required configuration keys are registered.
-func Initialize(cfg configura.Config) error {
+func Initialize(cfg *configura.Config) error {
 	// Validate that the config instance has all the keys our service needs
 	if err := cfg.Exists(RequiredUserServiceKeys...); err != nil {
 		return fmt.Errorf("user service configuration validation failed: %w", err)
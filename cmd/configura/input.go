@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kansuler/configura"
+	"gopkg.in/yaml.v3"
+)
+
+// input is an environment that a service may run with.
+type input struct {
+	name string
+	src  configura.Source
+	// keys lists every variable that the environment sets, or is nil if it cannot be listed, as for the environment of
+	// the process.
+	keys []string
+	// external lists the variables that a Kubernetes env list sets from a secret, config map or field, whose values
+	// are only known once the pod runs.
+	external map[string]bool
+}
+
+// readInput reads the environment named by arg: "env" for the environment of the process, a path ending in .yaml,
+// .yml or .json for a Kubernetes env list, or the path of a .env file otherwise.
+func readInput(arg string) (input, error) {
+	switch {
+	case arg == "env":
+		return input{name: arg, src: configura.Env}, nil
+	case strings.HasSuffix(arg, ".yaml"), strings.HasSuffix(arg, ".yml"), strings.HasSuffix(arg, ".json"):
+		return readKubernetes(arg)
+	}
+
+	values, err := configura.DotenvFile(arg)
+	if err != nil {
+		return input{}, err
+	}
+	in := input{name: filepath.Base(arg), src: values}
+	for key := range values {
+		in.keys = append(in.keys, key)
+	}
+	return in, nil
+}
+
+// envVar is an entry of the env list of a Kubernetes container.
+type envVar struct {
+	Name      string     `yaml:"name"`
+	Value     *string    `yaml:"value"`
+	ValueFrom *yaml.Node `yaml:"valueFrom"`
+}
+
+// readKubernetes reads the env list of a Kubernetes container from the file at path. The file may hold the list
+// itself, or any manifest that holds it, such as a Deployment, in which case the env lists of every container are
+// combined.
+func readKubernetes(path string) (input, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return input{}, err
+	}
+
+	var lists []yaml.Node
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return input{}, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.SequenceNode {
+			lists = append(lists, *doc.Content[0])
+		} else {
+			lists = append(lists, envLists(&doc)...)
+		}
+	}
+
+	in := input{name: filepath.Base(path), external: make(map[string]bool)}
+	values := configura.MapSource{}
+	for _, list := range lists {
+		var vars []envVar
+		if err := list.Decode(&vars); err != nil {
+			return input{}, fmt.Errorf("%s: line %d: %w", path, list.Line, err)
+		}
+		for _, v := range vars {
+			if v.Name == "" {
+				continue
+			}
+			in.keys = append(in.keys, v.Name)
+			switch {
+			case v.ValueFrom != nil:
+				in.external[v.Name] = true
+			case v.Value != nil:
+				values[v.Name] = *v.Value
+			default:
+				values[v.Name] = ""
+			}
+		}
+	}
+	in.src = values
+	return in, nil
+}
+
+// envLists returns every sequence held under an "env" key within node.
+func envLists(node *yaml.Node) []yaml.Node {
+	var lists []yaml.Node
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "env" && node.Content[i+1].Kind == yaml.SequenceNode {
+				lists = append(lists, *node.Content[i+1])
+				continue
+			}
+			lists = append(lists, envLists(node.Content[i+1])...)
+		}
+		return lists
+	}
+	for _, child := range node.Content {
+		lists = append(lists, envLists(child)...)
+	}
+	return lists
+}
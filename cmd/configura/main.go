@@ -0,0 +1,287 @@
+// Command configura checks environments against the variables that a service declares in a schema file, and
+// generates documentation and dumps from it.
+//
+// Usage:
+//
+//	configura check [-schema file] [input]
+//	configura dump [-schema file] [-format text|json] [input]
+//	configura docs [-schema file] [-format markdown|html|dotenv]
+//	configura diff [-schema file] input input
+//
+// An input is "env" for the environment of the process, which is the default, a path ending in .yaml, .yml or .json
+// for the env list of a Kubernetes container or any manifest that holds one, or the path of a .env file otherwise.
+//
+// The schema file lists the variables in YAML or JSON:
+//
+//	variables:
+//	  - name: DATABASE_URL
+//	    type: string
+//	    required: true
+//	    sensitive: true
+//	    description: Connection string of the primary database
+//	  - name: PORT
+//	    type: int
+//	    fallback: 3000
+//
+// check exits with status 1 if a required variable is missing, a value cannot be parsed, or a .env file or Kubernetes
+// env list sets a variable that the schema does not declare. diff exits with status 1 if the inputs differ. Invalid
+// arguments and unreadable files exit with status 2.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+
+	"github.com/Kansuler/configura"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = `usage:
+  configura check [-schema file] [input]
+  configura dump [-schema file] [-format text|json] [input]
+  configura docs [-schema file] [-format markdown|html|dotenv]
+  configura diff [-schema file] input input
+
+An input is "env" for the environment of the process, a path ending in .yaml,
+.yml or .json for a Kubernetes env list, or the path of a .env file.
+`
+
+// errUsage is returned for invalid arguments, whose explanation was already written.
+var errUsage = errors.New("usage")
+
+// run runs the command with args, and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	commands := map[string]func(args []string, stdout, stderr io.Writer) (bool, error){
+		"check": check,
+		"dump":  dump,
+		"docs":  docs,
+		"diff":  diff,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "configura: unknown command %q\n%s", args[0], usage)
+		return 2
+	}
+
+	passed, err := cmd(args[1:], stdout, stderr)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	case err != nil:
+		fmt.Fprintf(stderr, "configura %s: %v\n", args[0], err)
+		return 2
+	case !passed:
+		return 1
+	}
+	return 0
+}
+
+// parseFlags parses the flags of a command, and returns the schema along with the inputs given as arguments, of
+// which there may be between min and max. If none are given, "env" is used for each of the min inputs.
+func parseFlags(fs *flag.FlagSet, args []string, stderr io.Writer, min, max int) ([]variable, []input, error) {
+	schemaPath := fs.String("schema", "configura.yaml", "the schema `file` that declares the variables")
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, nil, err
+		}
+		return nil, nil, errUsage
+	}
+	if fs.NArg() > max || (fs.NArg() > 0 && fs.NArg() < min) {
+		fmt.Fprintf(stderr, "configura %s: expected %s, got %d\n%s", fs.Name(), inputCount(min, max), fs.NArg(), usage)
+		return nil, nil, errUsage
+	}
+
+	vars, err := readSchema(*schemaPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := fs.Args()
+	for len(names) < min {
+		names = append(names, "env")
+	}
+	inputs := make([]input, len(names))
+	for i, name := range names {
+		if inputs[i], err = readInput(name); err != nil {
+			return nil, nil, err
+		}
+	}
+	return vars, inputs, nil
+}
+
+// inputCount describes how many inputs a command takes, given that it takes between min and max.
+func inputCount(min, max int) string {
+	switch {
+	case max == 0:
+		return "no inputs"
+	case min == max && max == 1:
+		return "1 input"
+	case min == max:
+		return fmt.Sprintf("%d inputs", max)
+	}
+	return fmt.Sprintf("%d to %d inputs", min, max)
+}
+
+// check reports the variables of the schema that are missing or cannot be parsed in an input, the variables that
+// the input sets but the schema does not declare, and the deprecated variables that it still sets.
+func check(args []string, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	vars, inputs, err := parseFlags(fs, args, stderr, 1, 1)
+	if err != nil {
+		return false, err
+	}
+	in := inputs[0]
+	cfg, errs := load(vars, in)
+
+	var missing, unparsable, unchecked []string
+	declared := make(map[string]bool)
+	for _, v := range vars {
+		declared[v.Name] = true
+		err, ok := errs[v.Name]
+		switch {
+		case in.external[v.Name]:
+			unchecked = append(unchecked, v.Name)
+		case !ok:
+		case errors.Is(err, configura.ErrMissingVariable):
+			missing = append(missing, v.Name+describe(v.Description))
+		default:
+			unparsable = append(unparsable, err.Error())
+		}
+	}
+	var unknown []string
+	for _, key := range in.keys {
+		if !declared[key] && !slices.Contains(unknown, key) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+
+	for _, line := range missing {
+		fmt.Fprintf(stdout, "missing: %s\n", line)
+	}
+	for _, line := range unparsable {
+		fmt.Fprintf(stdout, "unparsable: %s\n", line)
+	}
+	for _, key := range unknown {
+		fmt.Fprintf(stdout, "unknown: %s\n", key)
+	}
+	for _, p := range cfg.Deprecated() {
+		i := slices.IndexFunc(vars, func(v variable) bool { return v.Name == p.Key })
+		fmt.Fprintf(stdout, "deprecated: %s: %s\n", p.Key, vars[i].Deprecated)
+	}
+	for _, key := range unchecked {
+		fmt.Fprintf(stdout, "unchecked: %s is set through valueFrom\n", key)
+	}
+
+	if problems := len(missing) + len(unparsable) + len(unknown); problems > 0 {
+		fmt.Fprintf(stdout, "%s: %d missing, %d unparsable, %d unknown\n", in.name, len(missing), len(unparsable), len(unknown))
+		return false, nil
+	}
+	fmt.Fprintf(stdout, "%s: %d variables ok\n", in.name, len(vars))
+	return true, nil
+}
+
+// describe returns description in parentheses, prefixed with a space, or an empty string if there is none.
+func describe(description string) string {
+	if description == "" {
+		return ""
+	}
+	return " (" + description + ")"
+}
+
+// dump writes the values that the variables of the schema take in an input, with sensitive values masked.
+func dump(args []string, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("dump", flag.ContinueOnError)
+	format := fs.String("format", "text", "the output `format`: text or json")
+	vars, inputs, err := parseFlags(fs, args, stderr, 1, 1)
+	if err != nil {
+		return false, err
+	}
+	cfg, _ := load(vars, inputs[0])
+
+	switch *format {
+	case "text":
+		return true, cfg.DumpText(stdout)
+	case "json":
+		return true, cfg.DumpJSON(stdout)
+	}
+	return false, fmt.Errorf("unknown format %q", *format)
+}
+
+// docs writes the reference documentation of the variables of the schema.
+func docs(args []string, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("docs", flag.ContinueOnError)
+	format := fs.String("format", "markdown", "the output `format`: markdown, html or dotenv")
+	vars, _, err := parseFlags(fs, args, stderr, 0, 0)
+	if err != nil {
+		return false, err
+	}
+	cfg, _ := load(vars, input{name: "schema", src: configura.MapSource{}})
+
+	switch *format {
+	case "markdown":
+		return true, cfg.ReferenceMarkdown(stdout)
+	case "html":
+		return true, cfg.ReferenceHTML(stdout)
+	case "dotenv":
+		return true, cfg.DotenvExample(stdout)
+	}
+	return false, fmt.Errorf("unknown format %q", *format)
+}
+
+// diff writes the variables of the schema whose values differ between two inputs, with sensitive values masked.
+func diff(args []string, stdout, stderr io.Writer) (bool, error) {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	vars, inputs, err := parseFlags(fs, args, stderr, 2, 2)
+	if err != nil {
+		return false, err
+	}
+	from, _ := load(vars, inputs[0])
+	to, _ := load(vars, inputs[1])
+	fromValues, toValues := values(from), values(to)
+
+	same := true
+	for _, v := range vars {
+		old, oldOK := fromValues[v.Name]
+		new, newOK := toValues[v.Name]
+		if oldOK == newOK && reflect.DeepEqual(v.get(from), v.get(to)) {
+			continue
+		}
+		same = false
+		fmt.Fprintf(stdout, "%s: %s -> %s\n", v.Name, orUnset(old, oldOK), orUnset(new, newOK))
+	}
+	return same, nil
+}
+
+// values returns the values of the variables that are registered in cfg by name, with sensitive values masked.
+func values(cfg *configura.Config) map[string]string {
+	values := make(map[string]string)
+	for _, s := range cfg.Dump() {
+		values[s.Key] = s.Value
+	}
+	return values
+}
+
+// orUnset returns value, or "(unset)" if ok is false.
+func orUnset(value string, ok bool) string {
+	if !ok {
+		return "(unset)"
+	}
+	return value
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Kansuler/configura"
+	"gopkg.in/yaml.v3"
+)
+
+// schema declares the variables of a service, as read from a schema file.
+type schema struct {
+	Variables []spec `yaml:"variables"`
+}
+
+// spec declares a single variable. Fallback is given in the raw form that Load accepts, and must parse as Type.
+type spec struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Fallback    string `yaml:"fallback"`
+	Required    bool   `yaml:"required"`
+	Sensitive   bool   `yaml:"sensitive"`
+	Description string `yaml:"description"`
+	Example     string `yaml:"example"`
+	Owner       string `yaml:"owner"`
+	Deprecated  string `yaml:"deprecated"`
+}
+
+// variable is a spec bound to the Go type named by its Type.
+type variable struct {
+	spec
+	// load declares the variable in cfg, and returns the error of LoadStrict or LoadRequired.
+	load func(cfg *configura.Config) error
+	// get returns the value of the variable in cfg.
+	get func(cfg *configura.Config) any
+}
+
+// binder binds a spec to a Variable[T], and checks that its fallback parses as T.
+type binder func(s spec) (variable, error)
+
+// types maps the names of the types that a schema may use, as given by Dump and Reference, to their binders.
+var types = map[string]binder{
+	"string":             bind[string],
+	"int":                bind[int],
+	"int8":               bind[int8],
+	"int16":              bind[int16],
+	"int32":              bind[int32],
+	"int64":              bind[int64],
+	"uint":               bind[uint],
+	"uint8":              bind[uint8],
+	"uint16":             bind[uint16],
+	"uint32":             bind[uint32],
+	"uint64":             bind[uint64],
+	"uintptr":            bind[uintptr],
+	"[]byte":             bind[[]byte],
+	"[]rune":             bind[[]rune],
+	"float32":            bind[float32],
+	"float64":            bind[float64],
+	"bool":               bind[bool],
+	"time.Duration":      bind[time.Duration],
+	"[]string":           bind[[]string],
+	"[]int":              bind[[]int],
+	"[]float64":          bind[[]float64],
+	"[]bool":             bind[[]bool],
+	"map[string]string":  bind[map[string]string],
+	"map[string]int":     bind[map[string]int],
+	"map[string]int64":   bind[map[string]int64],
+	"map[string]float64": bind[map[string]float64],
+}
+
+// bind binds s to a Variable[T], and describes it with the metadata of s.
+func bind[T any](s spec) (variable, error) {
+	key := configura.Describe(configura.Variable[T](s.Name), configura.Metadata{
+		Description: s.Description,
+		Example:     s.Example,
+		Owner:       s.Owner,
+		Deprecated:  s.Deprecated,
+		Sensitive:   s.Sensitive,
+	})
+
+	var fallback T
+	if s.Fallback != "" && !s.Required {
+		cfg := configura.New(configura.MapSource{s.Name: s.Fallback})
+		if err := configura.LoadStrict(cfg, key, fallback); err != nil {
+			return variable{}, fmt.Errorf("fallback of %w", err)
+		}
+		fallback = configura.Get(cfg, key)
+	}
+	return variable{
+		spec: s,
+		load: func(cfg *configura.Config) error {
+			if s.Required {
+				return configura.LoadRequired(cfg, key)
+			}
+			return configura.LoadStrict(cfg, key, fallback)
+		},
+		get: func(cfg *configura.Config) any {
+			return configura.Get(cfg, key)
+		},
+	}, nil
+}
+
+// readSchema reads the schema file at path, in YAML or JSON, binds its variables, and returns them in order of name,
+// so that the output of every command is ordered by name whatever the order of the file.
+func readSchema(path string) ([]variable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	vars := make([]variable, 0, len(s.Variables))
+	seen := make(map[string]bool)
+	for i, sp := range s.Variables {
+		if sp.Name == "" {
+			return nil, fmt.Errorf("%s: variable %d has no name", path, i+1)
+		}
+		if seen[sp.Name] {
+			return nil, fmt.Errorf("%s: variable %s is declared twice", path, sp.Name)
+		}
+		seen[sp.Name] = true
+		if sp.Type == "" {
+			sp.Type = "string"
+		}
+		b, ok := types[sp.Type]
+		if !ok {
+			return nil, fmt.Errorf("%s: variable %s has unsupported type %q", path, sp.Name, sp.Type)
+		}
+		v, err := b(sp)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		vars = append(vars, v)
+	}
+	slices.SortFunc(vars, func(a, b variable) int { return strings.Compare(a.Name, b.Name) })
+	return vars, nil
+}
+
+// load declares every variable in a new configuration that reads in, and returns the error of each variable that is
+// missing or cannot be parsed, by name.
+func load(vars []variable, in input) (*configura.Config, map[string]error) {
+	cfg := configura.New()
+	cfg.SetLayers(configura.Layer{Name: in.name, Source: in.src})
+	errs := make(map[string]error)
+	for _, v := range vars {
+		if err := v.load(cfg); err != nil {
+			errs[v.Name] = err
+		}
+	}
+	return cfg, errs
+}
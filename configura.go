@@ -2,374 +2,464 @@ package configura
 
 import (
 	"errors"
+	"fmt"
 	"maps"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-var ErrMissingVariable = errors.New("missing configuration variables")
-
-type constraint interface {
-	string | int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr | []byte | []rune | float32 | float64 | bool
-}
+var (
+	ErrMissingVariable = errors.New("missing configuration variables")
+	ErrInvalidVariable = errors.New("invalid configuration variables")
+	ErrTypeMismatch    = errors.New("mismatched type")
+)
 
-type Variable[T constraint] string
+// Variable is the key of a configuration variable holding values of type T. Variables can be of any of the types
+// listed under Supported Types in the README, or of a type registered with RegisterType.
+type Variable[T any] string
 
 // Write is a generic function that writes configuration values to the provided configuration struct.
 // It uses type assertions to determine the type of the values and writes them to the appropriate map in the
-// configuration struct.
-func Write[T constraint](cfg *Config, values map[Variable[T]]T) error {
+// configuration struct. If any value violates a rule attached with Validate, nothing is written and a
+// ValidationError is returned.
+func Write[T any](cfg *Config, values map[Variable[T]]T) error {
 	if cfg == nil {
 		return errors.New("Config cannot be nil")
 	}
 
-	cfg.rwLock.Lock()
-	defer cfg.rwLock.Unlock()
-	switch v := any(values).(type) {
-	case map[Variable[string]]string:
-		maps.Copy(cfg.regString, v)
-	case map[Variable[int]]int:
-		maps.Copy(cfg.regInt, v)
-	case map[Variable[int8]]int8:
-		maps.Copy(cfg.regInt8, v)
-	case map[Variable[int16]]int16:
-		maps.Copy(cfg.regInt16, v)
-	case map[Variable[int32]]int32:
-		maps.Copy(cfg.regInt32, v)
-	case map[Variable[int64]]int64:
-		maps.Copy(cfg.regInt64, v)
-	case map[Variable[uint]]uint:
-		maps.Copy(cfg.regUint, v)
-	case map[Variable[uint8]]uint8:
-		maps.Copy(cfg.regUint8, v)
-	case map[Variable[uint16]]uint16:
-		maps.Copy(cfg.regUint16, v)
-	case map[Variable[uint32]]uint32:
-		maps.Copy(cfg.regUint32, v)
-	case map[Variable[uint64]]uint64:
-		maps.Copy(cfg.regUint64, v)
-	case map[Variable[uintptr]]uintptr:
-		maps.Copy(cfg.regUintptr, v)
-	case map[Variable[[]byte]][]byte:
-		maps.Copy(cfg.regBytes, v)
-	case map[Variable[[]rune]][]rune:
-		maps.Copy(cfg.regRunes, v)
-	case map[Variable[float32]]float32:
-		maps.Copy(cfg.regFloat32, v)
-	case map[Variable[float64]]float64:
-		maps.Copy(cfg.regFloat64, v)
-	case map[Variable[bool]]bool:
-		maps.Copy(cfg.regBool, v)
-	default:
-		return errors.New("unsupported values type")
+	defer cfg.unlock(cfg.lock())
+	if err := validate(cfg, values); err != nil {
+		return err
+	}
+	write(cfg, values)
+	now := time.Now()
+	for key := range values {
+		cfg.written[key] = struct{}{}
+		cfg.origins[key] = Origin{Kind: OriginWrite, Time: now}
 	}
-
 	return nil
 }
 
-// Load is a generic function that loads an environment variable into the provided configuration,
-// using the specified key and fallback value. It uses type assertions to determine the type of the key
-// and fallback value, and registers the variable in the appropriate map of the configuration struct.
-func Load[T constraint](cfg *Config, key Variable[T], fallback T) {
-	cfg.rwLock.Lock()
-	defer cfg.rwLock.Unlock()
-	switch k := any(key).(type) {
-	case Variable[string]:
-		if _, ok := cfg.regString[k]; ok {
-			return
-		}
-		cfg.regString[k] = String(k, any(fallback).(string))
-	case Variable[int]:
-		if _, ok := cfg.regInt[k]; ok {
-			return
-		}
-		cfg.regInt[k] = Int(k, any(fallback).(int))
-	case Variable[int8]:
-		if _, ok := cfg.regInt8[k]; ok {
-			return
-		}
-		cfg.regInt8[k] = Int8(k, any(fallback).(int8))
-	case Variable[int16]:
-		if _, ok := cfg.regInt16[k]; ok {
-			return
-		}
-		cfg.regInt16[k] = Int16(k, any(fallback).(int16))
-	case Variable[int32]:
-		if _, ok := cfg.regInt32[k]; ok {
-			return
-		}
-		cfg.regInt32[k] = Int32(k, any(fallback).(int32))
-	case Variable[int64]:
-		if _, ok := cfg.regInt64[k]; ok {
-			return
-		}
-		cfg.regInt64[k] = Int64(k, any(fallback).(int64))
-	case Variable[uint]:
-		if _, ok := cfg.regUint[k]; ok {
-			return
-		}
-		cfg.regUint[k] = Uint(k, any(fallback).(uint))
-	case Variable[uint8]:
-		if _, ok := cfg.regUint8[k]; ok {
-			return
-		}
-		cfg.regUint8[k] = Uint8(k, any(fallback).(uint8))
-	case Variable[uint16]:
-		if _, ok := cfg.regUint16[k]; ok {
-			return
-		}
-		cfg.regUint16[k] = Uint16(k, any(fallback).(uint16))
-	case Variable[uint32]:
-		if _, ok := cfg.regUint32[k]; ok {
-			return
-		}
-		cfg.regUint32[k] = Uint32(k, any(fallback).(uint32))
-	case Variable[uint64]:
-		if _, ok := cfg.regUint64[k]; ok {
-			return
-		}
-		cfg.regUint64[k] = Uint64(k, any(fallback).(uint64))
-	case Variable[uintptr]:
-		if _, ok := cfg.regUintptr[k]; ok {
-			return
-		}
-		cfg.regUintptr[k] = Uintptr(k, any(fallback).(uintptr))
-	case Variable[[]byte]:
-		if _, ok := cfg.regBytes[k]; ok {
-			return
-		}
-		cfg.regBytes[k] = Bytes(k, any(fallback).([]byte))
-	case Variable[[]rune]:
-		if _, ok := cfg.regRunes[k]; ok {
-			return
+// write copies values into the registry, and records whether they violate the rules of their variables. The caller
+// must hold the write lock.
+func write[T any](cfg *Config, values map[Variable[T]]T) {
+	r := cfg.mutable()
+	for key, value := range values {
+		r.values[key] = value
+		cfg.check(key, value)
+	}
+}
+
+// Load is a generic function that loads a configuration variable into the provided configuration,
+// using the specified key and fallback value. The raw value is looked up in the layers of the configuration, or in
+// sources if any are given, and converted to the type of the key. The fallback is registered instead if no layer
+// holds the variable or it cannot be converted. A key that is already loaded or written is left untouched. Conversion
+// failures are only reported when strict mode is enabled, see SetStrict.
+func Load[T any](cfg *Config, key Variable[T], fallback T, sources ...Source) {
+	defer cfg.unlock(cfg.lock())
+	declare(cfg, &declared[T]{key: key, fallback: fallback, sources: sources})
+}
+
+// LoadStrict works like Load, but reports a value that is set but cannot be converted to the type of the key. The
+// fallback is still registered in that case, and the error is recorded so that Config.Err reports it together with
+// every other failure. A key that was already loaded keeps its first declaration, but becomes strict and is resolved
+// again, so that a value that cannot be converted is reported all the same. A registered value that violates a rule
+// attached with Validate is reported as a ValidationError.
+func LoadStrict[T any](cfg *Config, key Variable[T], fallback T, sources ...Source) error {
+	defer cfg.unlock(cfg.lock())
+	if err := declare(cfg, &declared[T]{key: key, fallback: fallback, sources: sources, strict: true}); err != nil {
+		return *err
+	}
+	return cfg.violated(key)
+}
+
+// LoadRequired loads a configuration variable that has no fallback, from the layers of the configuration or from
+// sources if any are given. If no layer holds the variable, nothing is registered and a MissingVariableError is
+// returned. If it is set but cannot be converted, nothing is registered either and the ParseError is returned. In
+// both cases the failure is also reported by Config.Err, so every required variable can be loaded up front and
+// checked once. A key that was already loaded with a fallback becomes required, and its fallback is dropped.
+func LoadRequired[T any](cfg *Config, key Variable[T], sources ...Source) error {
+	defer cfg.unlock(cfg.lock())
+	if err := declare(cfg, &declared[T]{key: key, sources: sources, required: true}); err != nil {
+		return *err
+	}
+	if _, ok := cfg.hasKey(key); !ok {
+		return newMissingVariableError([]any{key})
+	}
+	return cfg.violated(key)
+}
+
+// declare records the declaration of a variable and resolves its value. A variable that already has a value keeps its
+// first declaration, while one that has none, such as a required variable that was missing, takes the new declaration.
+// A strict declaration makes the first declaration strict and resolves it again, so that the failure to parse its value
+// is reported, and a required declaration replaces a declaration with a fallback. A variable that was written with
+// Write keeps its value. The caller must hold the write lock.
+func declare[T any](cfg *Config, d *declared[T]) *ParseError {
+	if d.required && !slices.Contains(cfg.required, any(d.key)) {
+		cfg.required = append(cfg.required, d.key)
+	}
+	if prev, ok := cfg.declarations[d.key].(*declared[T]); ok {
+		if _, ok := cfg.hasKey(d.key); ok {
+			switch {
+			case d.required && !prev.required:
+			case d.strict || d.required:
+				// The declaration may be shared with a merged configuration, so it is copied rather than changed.
+				upgraded := *prev
+				upgraded.strict = upgraded.strict || d.strict
+				d = &upgraded
+			default:
+				return nil
+			}
 		}
-		cfg.regRunes[k] = Runes(k, any(fallback).([]rune))
-	case Variable[float32]:
-		if _, ok := cfg.regFloat32[k]; ok {
-			return
+	}
+	cfg.declarations[d.key] = d
+
+	if _, ok := cfg.written[d.key]; ok {
+		return nil
+	}
+	return d.resolve(cfg)
+}
+
+// resolve looks up key in sources, or in the layers of cfg if none are given, and converts the value of the source
+// that takes precedence to the type of key. Values of a ValueSource are converted directly, while those of any other
+// source are parsed from their string form. ok reports whether any source holds the key, and origin records which one
+// does. The caller must hold the lock.
+func resolve[T any](cfg *Config, key Variable[T], sources []Source) (value T, origin Origin, ok bool, parseErr *ParseError) {
+	layers := cfg.layers
+	if len(sources) > 0 {
+		layers = make([]Layer, len(sources))
+		for i, src := range sources {
+			layers[i] = Layer{Name: SourceName(src), Source: src}
 		}
-		cfg.regFloat32[k] = Float32(k, any(fallback).(float32))
-	case Variable[float64]:
-		if _, ok := cfg.regFloat64[k]; ok {
-			return
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		origin = Origin{Kind: OriginSource, Source: layers[i].Name, Time: time.Now()}
+		if src, isValueSource := layers[i].Source.(ValueSource); isValueSource {
+			v, found := src.LookupValue(string(key))
+			if !found {
+				continue
+			}
+			origin.Location = v.Location
+			value, err := convert(cfg, key, v.Data)
+			if err != nil {
+				parseErr = &ParseError{Key: string(key), Value: formatValue(v.Data), Type: typeName(key), Location: v.Location, Err: err}
+				cfg.redact(parseErr)
+				return value, origin, true, parseErr
+			}
+			return value, origin, true, nil
 		}
-		cfg.regFloat64[k] = Float64(k, any(fallback).(float64))
-	case Variable[bool]:
-		if _, ok := cfg.regBool[k]; ok {
-			return
+
+		if raw, found := layers[i].Source.Lookup(string(key)); found {
+			value, err := parse(cfg, key, raw)
+			if err != nil {
+				parseErr = &ParseError{Key: string(key), Value: raw, Type: typeName(key), Err: err}
+				cfg.redact(parseErr)
+				return value, origin, true, parseErr
+			}
+			return value, origin, true, nil
 		}
-		cfg.regBool[k] = Bool(k, any(fallback).(bool))
 	}
+
+	return value, Origin{}, false, nil
 }
 
-// config is a concrete implementation of the Config interface, holding maps for each type of configuration
+// config is a concrete implementation of the Config interface, holding the registry of every configuration
 // variable. It provides methods to retrieve values for each type and checks if all required keys are registered.
+// A Config must be created with New. The getters, Get, Exists and Err of a zero Config behave as if nothing was
+// registered, while loading or writing into it panics.
 type Config struct {
-	rwLock     sync.RWMutex
-	regString  map[Variable[string]]string
-	regInt     map[Variable[int]]int
-	regInt8    map[Variable[int8]]int8
-	regInt16   map[Variable[int16]]int16
-	regInt32   map[Variable[int32]]int32
-	regInt64   map[Variable[int64]]int64
-	regUint    map[Variable[uint]]uint
-	regUint8   map[Variable[uint8]]uint8
-	regUint16  map[Variable[uint16]]uint16
-	regUint32  map[Variable[uint32]]uint32
-	regUint64  map[Variable[uint64]]uint64
-	regUintptr map[Variable[uintptr]]uintptr
-	regBytes   map[Variable[[]byte]][]byte
-	regRunes   map[Variable[[]rune]][]rune
-	regFloat32 map[Variable[float32]]float32
-	regFloat64 map[Variable[float64]]float64
-	regBool    map[Variable[bool]]bool
-}
-
-func New() *Config {
-	return &Config{
-		regString:  make(map[Variable[string]]string),
-		regInt:     make(map[Variable[int]]int),
-		regInt8:    make(map[Variable[int8]]int8),
-		regInt16:   make(map[Variable[int16]]int16),
-		regInt32:   make(map[Variable[int32]]int32),
-		regInt64:   make(map[Variable[int64]]int64),
-		regUint:    make(map[Variable[uint]]uint),
-		regUint8:   make(map[Variable[uint8]]uint8),
-		regUint16:  make(map[Variable[uint16]]uint16),
-		regUint32:  make(map[Variable[uint32]]uint32),
-		regUint64:  make(map[Variable[uint64]]uint64),
-		regUintptr: make(map[Variable[uintptr]]uintptr),
-		regBytes:   make(map[Variable[[]byte]][]byte),
-		regRunes:   make(map[Variable[[]rune]][]rune),
-		regFloat32: make(map[Variable[float32]]float32),
-		regFloat64: make(map[Variable[float64]]float64),
-		regBool:    make(map[Variable[bool]]bool),
+	rwLock           sync.RWMutex
+	layers           []Layer
+	written          map[any]struct{}
+	strict           bool
+	rejectDeprecated bool
+	parseErrors      []ParseError
+	required         []any
+	declarations     map[any]declaration
+	origins          map[any]Origin
+	subscriptions    map[any][]*subscription
+	rules            map[any]validator
+	violations       map[any][]Violation
+	sensitive        map[any]struct{}
+	requiredBy       map[any][]string
+	requiredSeen     sync.Map
+	notifyLock       sync.Mutex
+	notifications    []notification
+	notifying        bool
+	listSeparator    string
+	entrySeparator   string
+	pairSeparator    string
+	current          atomic.Pointer[registry]
+	draft            *registry
+}
+
+// New creates an empty configuration that loads its variables from sources, each of which becomes a layer named by
+// SourceName. When a variable is present in more than one source, the source given last takes precedence, so
+// New(file, Env) lets the environment override a file. Without any sources, variables are loaded from the environment
+// through Env.
+func New(sources ...Source) *Config {
+	if len(sources) == 0 {
+		sources = []Source{Env}
 	}
+
+	layers := make([]Layer, len(sources))
+	for i, src := range sources {
+		layers[i] = Layer{Name: SourceName(src), Source: src}
+	}
+
+	cfg := &Config{
+		layers:         layers,
+		written:        make(map[any]struct{}),
+		declarations:   make(map[any]declaration),
+		origins:        make(map[any]Origin),
+		subscriptions:  make(map[any][]*subscription),
+		rules:          make(map[any]validator),
+		violations:     make(map[any][]Violation),
+		sensitive:      make(map[any]struct{}),
+		requiredBy:     make(map[any][]string),
+		listSeparator:  DefaultListSeparator,
+		entrySeparator: DefaultEntrySeparator,
+		pairSeparator:  DefaultPairSeparator,
+	}
+	cfg.current.Store(newRegistry())
+	return cfg
 }
 
 func (c *Config) String(key Variable[string]) string {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regString[key]; exists {
-		return value
-	}
-	return ""
+	return Get(c, key)
 }
 
 func (c *Config) Int(key Variable[int]) int {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regInt[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Int8(key Variable[int8]) int8 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regInt8[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Int16(key Variable[int16]) int16 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regInt16[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Int32(key Variable[int32]) int32 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regInt32[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Int64(key Variable[int64]) int64 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regInt64[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uint(key Variable[uint]) uint {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUint[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uint8(key Variable[uint8]) uint8 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUint8[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uint16(key Variable[uint16]) uint16 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUint16[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uint32(key Variable[uint32]) uint32 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUint32[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uint64(key Variable[uint64]) uint64 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUint64[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Uintptr(key Variable[uintptr]) uintptr {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regUintptr[key]; exists {
-		return value
-	}
-	return 0
+	return Get(c, key)
 }
 
 func (c *Config) Bytes(key Variable[[]byte]) []byte {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regBytes[key]; exists {
-		return value
-	}
-	return nil
+	return Get(c, key)
 }
 
 func (c *Config) Runes(key Variable[[]rune]) []rune {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regRunes[key]; exists {
-		return value
-	}
-	return nil
+	return Get(c, key)
 }
 
 func (c *Config) Float32(key Variable[float32]) float32 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regFloat32[key]; exists {
-		return value
-	}
-	return 0.0
+	return Get(c, key)
 }
 
 func (c *Config) Float64(key Variable[float64]) float64 {
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	if value, exists := c.regFloat64[key]; exists {
-		return value
-	}
-	return 0.0
+	return Get(c, key)
 }
 
 func (c *Config) Bool(key Variable[bool]) bool {
+	return Get(c, key)
+}
+
+func (c *Config) Duration(key Variable[time.Duration]) time.Duration {
+	return Get(c, key)
+}
+
+func (c *Config) Strings(key Variable[[]string]) []string {
+	return Get(c, key)
+}
+
+func (c *Config) Ints(key Variable[[]int]) []int {
+	return Get(c, key)
+}
+
+func (c *Config) Float64s(key Variable[[]float64]) []float64 {
+	return Get(c, key)
+}
+
+func (c *Config) Bools(key Variable[[]bool]) []bool {
+	return Get(c, key)
+}
+
+func (c *Config) StringMap(key Variable[map[string]string]) map[string]string {
+	return Get(c, key)
+}
+
+func (c *Config) IntMap(key Variable[map[string]int]) map[string]int {
+	return Get(c, key)
+}
+
+func (c *Config) Int64Map(key Variable[map[string]int64]) map[string]int64 {
+	return Get(c, key)
+}
+
+func (c *Config) Float64Map(key Variable[map[string]float64]) map[string]float64 {
+	return Get(c, key)
+}
+
+// SetListSeparator sets the separator that Load uses to split list values such as Variable[[]string]. The default
+// is DefaultListSeparator. An empty separator restores the default.
+func (c *Config) SetListSeparator(sep string) {
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	c.listSeparator = Fallback(sep, DefaultListSeparator)
+}
+
+// SetMapSeparators sets the separators that Load uses to parse map values such as Variable[map[string]string]. entry
+// separates the key/value pairs and pair separates a key from its value. The defaults are DefaultEntrySeparator and
+// DefaultPairSeparator, and an empty separator restores its default. If the resulting separators are equal, an error
+// is returned and the separators are left unchanged.
+func (c *Config) SetMapSeparators(entry, pair string) error {
+	entry, pair = Fallback(entry, DefaultEntrySeparator), Fallback(pair, DefaultPairSeparator)
+	if err := checkMapSeparators(entry, pair); err != nil {
+		return err
+	}
+
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	c.entrySeparator, c.pairSeparator = entry, pair
+	return nil
+}
+
+// SetStrict controls whether Config.Err reports the values loaded with Load that cannot be converted to the type of
+// their key, in the same way as LoadStrict does. It is disabled by default, in which case such values silently fall
+// back. Enabling it also reports the values that failed to convert before it was enabled.
+func (c *Config) SetStrict(strict bool) {
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	c.strict = strict
+}
+
+// Err reports every failure recorded while loading the configuration. Required variables that are still not
+// registered are reported as a MissingVariableError, values that failed to parse during strict or required loading
+// as an InvalidVariableError, variables of a type that is neither built in nor registered with RegisterType as an error
+// matching ErrUnsupportedType, however they were loaded, values that violate the rules attached with Validate as a
+// ValidationError, and deprecated variables that are still set as a DeprecatedVariableError if SetRejectDeprecated is
+// enabled. When several kinds of failure occurred, the errors are joined. Err returns nil if there were no failures.
+func (c *Config) Err() error {
 	c.rwLock.RLock()
 	defer c.rwLock.RUnlock()
-	if value, exists := c.regBool[key]; exists {
-		return value
+	return c.err()
+}
+
+// err is the lock-free part of Err. The caller must hold the lock.
+func (c *Config) err() error {
+	var errs []error
+	var missingKeys []any
+	for _, key := range c.required {
+		if _, ok := c.hasKey(key); !ok {
+			missingKeys = append(missingKeys, key)
+		}
 	}
-	return false
+	if len(missingKeys) > 0 {
+		errs = append(errs, newMissingVariableError(missingKeys))
+	}
+	if parseErrors := c.reportedParseErrors(); len(parseErrors) > 0 {
+		errs = append(errs, InvalidVariableError{Errors: parseErrors})
+	}
+	var unsupported []string
+	for _, decl := range c.sortedDeclarations() {
+		if !decl.supported() {
+			unsupported = append(unsupported, decl.name()+" ("+decl.typeName()+")")
+		}
+	}
+	if len(unsupported) > 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrUnsupportedType, strings.Join(unsupported, ", ")))
+	}
+	if c.rejectDeprecated {
+		if report := c.deprecated(); len(report) > 0 {
+			errs = append(errs, newDeprecatedVariableError(report))
+		}
+	}
+	var violations []Violation
+	for _, vs := range c.violations {
+		violations = append(violations, vs...)
+	}
+	if len(violations) > 0 {
+		errs = append(errs, newValidationError(violations))
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
 }
 
-// MissingVariableError is an error type that holds a list of missing configuration variable keys.
+// MissingVariableError is an error type that holds a list of missing configuration variable keys, along with the
+// descriptions of those that were described with Describe.
 type MissingVariableError struct {
-	Keys []string
+	Keys         []string
+	Descriptions map[string]string
+}
+
+// newMissingVariableError returns a MissingVariableError for keys.
+func newMissingVariableError(keys []any) MissingVariableError {
+	e := MissingVariableError{Keys: make([]string, len(keys))}
+	for i, key := range keys {
+		e.Keys[i] = keyName(key)
+		if desc := description(key); desc != "" {
+			if e.Descriptions == nil {
+				e.Descriptions = make(map[string]string)
+			}
+			e.Descriptions[e.Keys[i]] = desc
+		}
+	}
+	return e
 }
 
 // Error implements the error interface for missingVariableError.
 func (e MissingVariableError) Error() string {
-	return "missing configuration variables: " + formatKeys(e.Keys)
+	if len(e.Descriptions) == 0 {
+		return "missing configuration variables: " + formatKeys(e.Keys)
+	}
+	keys := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		keys[i] = key
+		if desc, ok := e.Descriptions[key]; ok {
+			keys[i] += " (" + desc + ")"
+		}
+	}
+	return "missing configuration variables: " + formatKeys(keys)
 }
 
-// Unwrap implements the Unwrap method for the error interface, allowing the error to be unwrapped to ErrMissingVariable.
+// Unwrap implements the Unwrap method for the error interface, allowing the error to be unwrapped to
+// ErrMissingVariable.
 func (e MissingVariableError) Unwrap() error {
 	return ErrMissingVariable
 }
@@ -391,87 +481,127 @@ func formatKeys(keys []string) string {
 
 var _ error = (*MissingVariableError)(nil)
 
-// checkKey checks if the provided key exists in the configuration. It uses type assertion to determine the type of the
-// key and checks the corresponding map in the configuration struct.
-func (c *Config) checkKey(key any) (string, bool) {
-	var exists bool
-	var keyName string
-	c.rwLock.RLock()
-	defer c.rwLock.RUnlock()
-	switch k := key.(type) {
-	case Variable[string]:
-		_, exists = c.regString[k]
-		keyName = string(k)
-	case Variable[int]:
-		_, exists = c.regInt[k]
-		keyName = string(k)
-	case Variable[int8]:
-		_, exists = c.regInt8[k]
-		keyName = string(k)
-	case Variable[int16]:
-		_, exists = c.regInt16[k]
-		keyName = string(k)
-	case Variable[int32]:
-		_, exists = c.regInt32[k]
-		keyName = string(k)
-	case Variable[int64]:
-		_, exists = c.regInt64[k]
-		keyName = string(k)
-	case Variable[uint]:
-		_, exists = c.regUint[k]
-		keyName = string(k)
-	case Variable[uint8]:
-		_, exists = c.regUint8[k]
-		keyName = string(k)
-	case Variable[uint16]:
-		_, exists = c.regUint16[k]
-		keyName = string(k)
-	case Variable[uint32]:
-		_, exists = c.regUint32[k]
-		keyName = string(k)
-	case Variable[uint64]:
-		_, exists = c.regUint64[k]
-		keyName = string(k)
-	case Variable[uintptr]:
-		_, exists = c.regUintptr[k]
-		keyName = string(k)
-	case Variable[[]byte]:
-		_, exists = c.regBytes[k]
-		keyName = string(k)
-	case Variable[[]rune]:
-		_, exists = c.regRunes[k]
-		keyName = string(k)
-	case Variable[float32]:
-		_, exists = c.regFloat32[k]
-		keyName = string(k)
-	case Variable[float64]:
-		_, exists = c.regFloat64[k]
-		keyName = string(k)
-	case Variable[bool]:
-		_, exists = c.regBool[k]
-		keyName = string(k)
-	}
-
-	return keyName, exists
+// ParseError describes a configuration variable that is set, but whose raw value cannot be converted to the type of
+// the variable. Location tells where a structured source such as a JSON file holds the value, and is empty for plain
+// sources such as the environment.
+type ParseError struct {
+	Key      string
+	Value    string
+	Type     string
+	Location string
+	Err      error
+}
+
+// Error implements the error interface for ParseError.
+func (e ParseError) Error() string {
+	if e.Location != "" {
+		return fmt.Sprintf("%s (%s): cannot parse %q as %s: %v", e.Key, e.Location, e.Value, e.Type, e.Err)
+	}
+	return fmt.Sprintf("%s: cannot parse %q as %s: %v", e.Key, e.Value, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying conversion error.
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+var _ error = (*ParseError)(nil)
+
+// InvalidVariableError is an error type that aggregates every ParseError recorded while loading a configuration.
+type InvalidVariableError struct {
+	Errors []ParseError
+}
+
+// Error implements the error interface for InvalidVariableError.
+func (e InvalidVariableError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid configuration variables: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap allows the error to be matched against ErrInvalidVariable, and against each individual ParseError.
+func (e InvalidVariableError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors)+1)
+	errs = append(errs, ErrInvalidVariable)
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+var _ error = (*InvalidVariableError)(nil)
+
+// hasKey checks if the provided key exists in the configuration, and returns its name. The caller must hold the lock.
+func (c *Config) hasKey(key any) (string, bool) {
+	_, exists := c.view().values[key]
+	return keyName(key), exists
+}
+
+// value returns the value of key, and whether it is registered in the configuration. The caller must hold the lock.
+func (c *Config) value(key any) (any, bool) {
+	value, ok := c.view().values[key]
+	return value, ok
+}
+
+// remove deletes key from the configuration. The caller must hold the write lock.
+func (c *Config) remove(key any) {
+	delete(c.origins, key)
+	delete(c.violations, key)
+	delete(c.mutable().values, key)
+}
+
+// keys returns every key that is registered in the configuration. The caller must hold the lock.
+func (c *Config) keys() []any {
+	return slices.Collect(maps.Keys(c.view().values))
 }
 
 // Exists checks if all provided keys are registered in the configuration. To ensure that the
 // client of the package have taken all required keys into consideration when building the configuration object.
+// The package that calls Exists is recorded as requiring the keys, and listed by Reference. Like the getters, Exists
+// reads the published values without taking a lock once its caller was recorded.
 func (c *Config) Exists(keys ...any) error {
-	var missingKeys []string
+	c.requireByCaller(keys)
+
+	r := c.registry()
+	var missingKeys []any
 	for _, key := range keys {
-		if keyName, ok := c.checkKey(key); !ok {
-			missingKeys = append(missingKeys, keyName)
+		if _, ok := r.values[key]; !ok {
+			missingKeys = append(missingKeys, key)
 		}
 	}
 
 	if len(missingKeys) > 0 {
-		return MissingVariableError{Keys: missingKeys}
+		return newMissingVariableError(missingKeys)
 	}
 
 	return nil
 }
 
+// reportedParseErrors returns the recorded parse errors that Err reports: every one of them in strict mode, and
+// otherwise those of variables loaded with LoadStrict or LoadRequired. The caller must hold the lock.
+func (c *Config) reportedParseErrors() []ParseError {
+	var reported []ParseError
+	for _, err := range c.parseErrors {
+		if c.strict || c.reportedNamed(err.Key, err.Type) {
+			reported = append(reported, err)
+		}
+	}
+	return reported
+}
+
+// reportedNamed reports whether the failure to parse the variable named name, of type typ, is reported whatever the
+// strict mode. The caller must hold the lock.
+func (c *Config) reportedNamed(name, typ string) bool {
+	for _, decl := range c.declarations {
+		if decl.name() == name && decl.typeName() == typ {
+			return decl.reported()
+		}
+	}
+	return false
+}
+
 // Fallback is a helper function that returns the fallback value if the provided value is empty.
 // Only works on comparable types, which includes basic types like int, string, bool, etc.
 func Fallback[T comparable](value T, fallback T) T {
@@ -482,34 +612,48 @@ func Fallback[T comparable](value T, fallback T) T {
 	return value
 }
 
-// Merge combines multiple Config instances into a single Config instance.
+// Merge combines multiple Config instances into a single Config instance. The merged values count as written with
+// Write, so they are kept when the layers of the merged configuration change, and their origin is OriginMerge.
 // To ensure a consistent view of the source configurations, it locks all
 // configuration types for reading during the merge operation.
 func Merge(cfgs ...*Config) *Config {
 	merged := New()
-	merged.rwLock.Lock()
-	defer merged.rwLock.Unlock()
+	defer merged.unlock(merged.lock())
+	r := merged.mutable()
 
 	for _, cfg := range cfgs {
 		cfg.rwLock.RLock()
-		maps.Copy(merged.regString, cfg.regString)
-		maps.Copy(merged.regInt, cfg.regInt)
-		maps.Copy(merged.regInt8, cfg.regInt8)
-		maps.Copy(merged.regInt16, cfg.regInt16)
-		maps.Copy(merged.regInt32, cfg.regInt32)
-		maps.Copy(merged.regInt64, cfg.regInt64)
-		maps.Copy(merged.regUint, cfg.regUint)
-		maps.Copy(merged.regUint8, cfg.regUint8)
-		maps.Copy(merged.regUint16, cfg.regUint16)
-		maps.Copy(merged.regUint32, cfg.regUint32)
-		maps.Copy(merged.regUint64, cfg.regUint64)
-		maps.Copy(merged.regUintptr, cfg.regUintptr)
-		maps.Copy(merged.regBytes, cfg.regBytes)
-		maps.Copy(merged.regRunes, cfg.regRunes)
-		maps.Copy(merged.regFloat32, cfg.regFloat32)
-		maps.Copy(merged.regFloat64, cfg.regFloat64)
-		maps.Copy(merged.regBool, cfg.regBool)
+		maps.Copy(r.values, cfg.registry().values)
+		now := time.Now()
+		for _, key := range cfg.keys() {
+			merged.written[key] = struct{}{}
+			origin := cfg.origins[key]
+			merged.origins[key] = Origin{Kind: OriginMerge, Source: origin.Source, Location: origin.Location, Time: now}
+		}
+		merged.parseErrors = append(merged.parseErrors, cfg.reportedParseErrors()...)
+		for _, key := range cfg.required {
+			if !slices.Contains(merged.required, key) {
+				merged.required = append(merged.required, key)
+			}
+		}
+		for key, decl := range cfg.declarations {
+			if _, ok := merged.declarations[key]; !ok {
+				merged.declarations[key] = decl
+			}
+		}
+		for key, v := range cfg.rules {
+			if _, ok := merged.rules[key]; !ok {
+				merged.rules[key] = v
+			}
+		}
+		maps.Copy(merged.sensitive, cfg.sensitive)
+		for key, pkgs := range cfg.requiredBy {
+			merged.requireBy(key, pkgs...)
+		}
 		cfg.rwLock.RUnlock()
 	}
+	for key, value := range r.values {
+		merged.check(key, value)
+	}
 	return merged
 }
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -27,6 +28,14 @@ type ExistsSuite struct {
 	suite.Suite
 }
 
+type StrictSuite struct {
+	suite.Suite
+}
+
+type RequiredSuite struct {
+	suite.Suite
+}
+
 type FallbackSuite struct {
 	suite.Suite
 }
@@ -245,6 +254,63 @@ func (s *ConfigSuite) TestBool() {
 	})
 }
 
+func (s *ConfigSuite) TestDuration() {
+	key := Variable[time.Duration]("TEST_DURATION")
+	s.Run("KeyNotExists", func() {
+		assert.Equal(s.T(), time.Duration(0), s.config.Duration(key))
+	})
+	s.Run("KeyExists", func() {
+		Write(s.config, map[Variable[time.Duration]]time.Duration{key: 90 * time.Second})
+		assert.Equal(s.T(), 90*time.Second, s.config.Duration(key))
+	})
+}
+
+func (s *ConfigSuite) TestSlices() {
+	stringsKey := Variable[[]string]("TEST_STRINGS")
+	intsKey := Variable[[]int]("TEST_INTS")
+	float64sKey := Variable[[]float64]("TEST_FLOAT64S")
+	boolsKey := Variable[[]bool]("TEST_BOOLS")
+	s.Run("KeyNotExists", func() {
+		assert.Nil(s.T(), s.config.Strings(stringsKey))
+		assert.Nil(s.T(), s.config.Ints(intsKey))
+		assert.Nil(s.T(), s.config.Float64s(float64sKey))
+		assert.Nil(s.T(), s.config.Bools(boolsKey))
+	})
+	s.Run("KeyExists", func() {
+		Write(s.config, map[Variable[[]string]][]string{stringsKey: {"a", "b"}})
+		Write(s.config, map[Variable[[]int]][]int{intsKey: {1, 2}})
+		Write(s.config, map[Variable[[]float64]][]float64{float64sKey: {1.5}})
+		Write(s.config, map[Variable[[]bool]][]bool{boolsKey: {true, false}})
+		assert.Equal(s.T(), []string{"a", "b"}, s.config.Strings(stringsKey))
+		assert.Equal(s.T(), []int{1, 2}, s.config.Ints(intsKey))
+		assert.Equal(s.T(), []float64{1.5}, s.config.Float64s(float64sKey))
+		assert.Equal(s.T(), []bool{true, false}, s.config.Bools(boolsKey))
+	})
+}
+
+func (s *ConfigSuite) TestMaps() {
+	stringMapKey := Variable[map[string]string]("TEST_STRING_MAP")
+	intMapKey := Variable[map[string]int]("TEST_INT_MAP")
+	int64MapKey := Variable[map[string]int64]("TEST_INT64_MAP")
+	float64MapKey := Variable[map[string]float64]("TEST_FLOAT64_MAP")
+	s.Run("KeyNotExists", func() {
+		assert.Nil(s.T(), s.config.StringMap(stringMapKey))
+		assert.Nil(s.T(), s.config.IntMap(intMapKey))
+		assert.Nil(s.T(), s.config.Int64Map(int64MapKey))
+		assert.Nil(s.T(), s.config.Float64Map(float64MapKey))
+	})
+	s.Run("KeyExists", func() {
+		Write(s.config, map[Variable[map[string]string]]map[string]string{stringMapKey: {"a": "b"}})
+		Write(s.config, map[Variable[map[string]int]]map[string]int{intMapKey: {"a": 1}})
+		Write(s.config, map[Variable[map[string]int64]]map[string]int64{int64MapKey: {"a": 2}})
+		Write(s.config, map[Variable[map[string]float64]]map[string]float64{float64MapKey: {"a": 0.5}})
+		assert.Equal(s.T(), map[string]string{"a": "b"}, s.config.StringMap(stringMapKey))
+		assert.Equal(s.T(), map[string]int{"a": 1}, s.config.IntMap(intMapKey))
+		assert.Equal(s.T(), map[string]int64{"a": 2}, s.config.Int64Map(int64MapKey))
+		assert.Equal(s.T(), map[string]float64{"a": 0.5}, s.config.Float64Map(float64MapKey))
+	})
+}
+
 // --- Test Methods for LoadSuite ---
 
 func (s *LoadSuite) setEnvVar(key string, value string) {
@@ -759,6 +825,137 @@ func (s *LoadSuite) TestLoadRunes() {
 	})
 }
 
+func (s *LoadSuite) TestLoadDuration() {
+	cfg := New()
+	key := Variable[time.Duration]("ENV_DURATION")
+	fallback := 30 * time.Second
+
+	s.Run("EnvVarSetValid", func() {
+		s.setEnvVar(string(key), "1m30s")
+		Load(cfg, key, fallback)
+		assert.Equal(s.T(), 90*time.Second, cfg.Duration(key))
+	})
+	s.Run("EnvVarNotSet", func() {
+		s.unsetEnvVar(string(key))
+		freshCfg := New()
+		Load(freshCfg, key, fallback)
+		assert.Equal(s.T(), fallback, freshCfg.Duration(key))
+	})
+	s.Run("EnvVarSetInvalid", func() {
+		s.setEnvVar(string(key), "30")
+		freshCfg := New()
+		Load(freshCfg, key, fallback)
+		assert.Equal(s.T(), fallback, freshCfg.Duration(key))
+	})
+}
+
+func (s *LoadSuite) TestLoadSlices() {
+	stringsKey := Variable[[]string]("ENV_STRINGS")
+	intsKey := Variable[[]int]("ENV_INTS")
+	float64sKey := Variable[[]float64]("ENV_FLOAT64S")
+	boolsKey := Variable[[]bool]("ENV_BOOLS")
+
+	s.Run("EnvVarSetValid", func() {
+		cfg := New()
+		s.setEnvVar(string(stringsKey), `a, "b,c" ,d`)
+		s.setEnvVar(string(intsKey), "1, 2, 3")
+		s.setEnvVar(string(float64sKey), "0.5,1.5")
+		s.setEnvVar(string(boolsKey), "true,false")
+		Load(cfg, stringsKey, nil)
+		Load(cfg, intsKey, nil)
+		Load(cfg, float64sKey, nil)
+		Load(cfg, boolsKey, nil)
+		assert.Equal(s.T(), []string{"a", "b,c", "d"}, cfg.Strings(stringsKey))
+		assert.Equal(s.T(), []int{1, 2, 3}, cfg.Ints(intsKey))
+		assert.Equal(s.T(), []float64{0.5, 1.5}, cfg.Float64s(float64sKey))
+		assert.Equal(s.T(), []bool{true, false}, cfg.Bools(boolsKey))
+	})
+	s.Run("EnvVarNotSet", func() {
+		cfg := New()
+		s.unsetEnvVar(string(stringsKey))
+		s.unsetEnvVar(string(intsKey))
+		Load(cfg, stringsKey, []string{"fallback"})
+		Load(cfg, intsKey, []int{42})
+		assert.Equal(s.T(), []string{"fallback"}, cfg.Strings(stringsKey))
+		assert.Equal(s.T(), []int{42}, cfg.Ints(intsKey))
+	})
+	s.Run("EnvVarSetInvalid", func() {
+		cfg := New()
+		s.setEnvVar(string(intsKey), "1,two")
+		s.setEnvVar(string(boolsKey), "true,nope")
+		Load(cfg, intsKey, []int{42})
+		Load(cfg, boolsKey, []bool{true})
+		assert.Equal(s.T(), []int{42}, cfg.Ints(intsKey))
+		assert.Equal(s.T(), []bool{true}, cfg.Bools(boolsKey))
+	})
+	s.Run("CustomSeparator", func() {
+		cfg := New()
+		cfg.SetListSeparator(";")
+		s.setEnvVar(string(stringsKey), "a,b;c")
+		Load(cfg, stringsKey, nil)
+		assert.Equal(s.T(), []string{"a,b", "c"}, cfg.Strings(stringsKey))
+	})
+	s.Run("EmptySeparatorRestoresDefault", func() {
+		cfg := New()
+		cfg.SetListSeparator("")
+		s.setEnvVar(string(stringsKey), "a,b")
+		Load(cfg, stringsKey, nil)
+		assert.Equal(s.T(), []string{"a", "b"}, cfg.Strings(stringsKey))
+	})
+}
+
+func (s *LoadSuite) TestLoadMaps() {
+	stringMapKey := Variable[map[string]string]("ENV_STRING_MAP")
+	intMapKey := Variable[map[string]int]("ENV_INT_MAP")
+	int64MapKey := Variable[map[string]int64]("ENV_INT64_MAP")
+	float64MapKey := Variable[map[string]float64]("ENV_FLOAT64_MAP")
+
+	s.Run("EnvVarSetValid", func() {
+		cfg := New()
+		s.setEnvVar(string(stringMapKey), "X-Request-Source=api, X-Team=core")
+		s.setEnvVar(string(intMapKey), "tenant_a=100,tenant_b=250")
+		s.setEnvVar(string(int64MapKey), "quota=5000000000")
+		s.setEnvVar(string(float64MapKey), "ratio=0.25")
+		Load(cfg, stringMapKey, nil)
+		Load(cfg, intMapKey, nil)
+		Load(cfg, int64MapKey, nil)
+		Load(cfg, float64MapKey, nil)
+		assert.Equal(s.T(), map[string]string{"X-Request-Source": "api", "X-Team": "core"}, cfg.StringMap(stringMapKey))
+		assert.Equal(s.T(), map[string]int{"tenant_a": 100, "tenant_b": 250}, cfg.IntMap(intMapKey))
+		assert.Equal(s.T(), map[string]int64{"quota": 5000000000}, cfg.Int64Map(int64MapKey))
+		assert.Equal(s.T(), map[string]float64{"ratio": 0.25}, cfg.Float64Map(float64MapKey))
+	})
+	s.Run("EnvVarNotSet", func() {
+		cfg := New()
+		s.unsetEnvVar(string(stringMapKey))
+		fallback := map[string]string{"X-Default": "yes"}
+		Load(cfg, stringMapKey, fallback)
+		assert.Equal(s.T(), fallback, cfg.StringMap(stringMapKey))
+	})
+	s.Run("EnvVarSetInvalid", func() {
+		cfg := New()
+		s.setEnvVar(string(intMapKey), "tenant_a=many")
+		fallback := map[string]int{"tenant_a": 1}
+		Load(cfg, intMapKey, fallback)
+		assert.Equal(s.T(), fallback, cfg.IntMap(intMapKey))
+	})
+	s.Run("CustomSeparators", func() {
+		cfg := New()
+		s.Require().NoError(cfg.SetMapSeparators(";", ":"))
+		s.setEnvVar(string(stringMapKey), "a:1,2;b:3")
+		Load(cfg, stringMapKey, nil)
+		assert.Equal(s.T(), map[string]string{"a": "1,2", "b": "3"}, cfg.StringMap(stringMapKey))
+	})
+	s.Run("EqualSeparators", func() {
+		cfg := New()
+		s.ErrorContains(cfg.SetMapSeparators(";", ";"), "must differ")
+		s.ErrorContains(cfg.SetMapSeparators("=", ""), "must differ", "An empty separator should resolve to its default first")
+		s.setEnvVar(string(stringMapKey), "a=1,b=2")
+		Load(cfg, stringMapKey, nil)
+		assert.Equal(s.T(), map[string]string{"a": "1", "b": "2"}, cfg.StringMap(stringMapKey), "The separators should be left unchanged")
+	})
+}
+
 func (s *LoadSuite) TestLoadDoesNotOverwriteExistingSettings() {
 	s.Run("StringType", func() {
 		cfg := New()
@@ -978,6 +1175,265 @@ func (s *ExistsSuite) TestExists() {
 	})
 }
 
+// --- Test Methods for StrictSuite ---
+
+func (s *StrictSuite) setEnvVar(key string, value string) {
+	s.Require().NoError(os.Setenv(key, value))
+	s.T().Cleanup(func() {
+		os.Unsetenv(key)
+	})
+}
+
+func (s *StrictSuite) TestLoadStrict() {
+	portKey := Variable[int]("STRICT_PORT")
+	hostKey := Variable[string]("STRICT_HOST")
+	timeoutKey := Variable[time.Duration]("STRICT_TIMEOUT")
+
+	s.Run("ValidValue", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "8080")
+		s.Require().NoError(LoadStrict(cfg, portKey, 3000))
+		s.Equal(8080, cfg.Int(portKey))
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("UnsetValueUsesFallback", func() {
+		cfg := New()
+		s.Require().NoError(LoadStrict(cfg, hostKey, "localhost"))
+		s.Equal("localhost", cfg.String(hostKey))
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("InvalidValue", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "80a0")
+		err := LoadStrict(cfg, portKey, 3000)
+		s.Require().Error(err)
+
+		var parseErr ParseError
+		s.Require().ErrorAs(err, &parseErr)
+		s.Equal(string(portKey), parseErr.Key)
+		s.Equal("80a0", parseErr.Value)
+		s.Equal("int", parseErr.Type)
+		s.ErrorIs(err, strconv.ErrSyntax)
+		s.Equal(`STRICT_PORT: cannot parse "80a0" as int: strconv.Atoi: parsing "80a0": invalid syntax`, err.Error())
+
+		s.Equal(3000, cfg.Int(portKey), "Fallback should still be registered")
+		s.NoError(cfg.Exists(portKey))
+	})
+
+	s.Run("AggregatesErrors", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "80a0")
+		s.setEnvVar(string(timeoutKey), "30")
+		s.Error(LoadStrict(cfg, portKey, 3000))
+		s.NoError(LoadStrict(cfg, hostKey, "localhost"))
+		s.Error(LoadStrict(cfg, timeoutKey, time.Second))
+
+		err := cfg.Err()
+		s.Require().Error(err)
+		s.ErrorIs(err, ErrInvalidVariable)
+
+		var invalidErr InvalidVariableError
+		s.Require().ErrorAs(err, &invalidErr)
+		s.Require().Len(invalidErr.Errors, 2)
+		s.Equal(string(portKey), invalidErr.Errors[0].Key)
+		s.Equal(string(timeoutKey), invalidErr.Errors[1].Key)
+		s.Equal("time.Duration", invalidErr.Errors[1].Type)
+		s.Contains(err.Error(), "invalid configuration variables: STRICT_PORT:")
+		s.Contains(err.Error(), "; STRICT_TIMEOUT:")
+
+		var parseErr ParseError
+		s.Require().ErrorAs(err, &parseErr)
+		s.Equal(string(portKey), parseErr.Key)
+	})
+
+	s.Run("AfterLoad", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "80a0")
+		Load(cfg, portKey, 3000)
+		s.Require().NoError(cfg.Err())
+
+		err := LoadStrict(cfg, portKey, 4000)
+		s.ErrorIs(err, strconv.ErrSyntax, "A strict declaration should report the value of an earlier declaration")
+		s.ErrorIs(cfg.Err(), ErrInvalidVariable)
+		s.Equal(3000, cfg.Int(portKey), "The first declaration should be kept")
+
+		s.ErrorIs(LoadStrict(cfg, portKey, 3000), strconv.ErrSyntax, "Loading the same key again should report it again")
+	})
+
+	s.Run("ExistingKeyIsNotReparsed", func() {
+		cfg := New()
+		Write(cfg, map[Variable[int]]int{portKey: 1234})
+		s.setEnvVar(string(portKey), "80a0")
+		s.NoError(LoadStrict(cfg, portKey, 3000))
+		s.Equal(1234, cfg.Int(portKey))
+		s.NoError(cfg.Err())
+	})
+}
+
+func (s *StrictSuite) TestSetStrict() {
+	portKey := Variable[int]("STRICT_MODE_PORT")
+	s.setEnvVar(string(portKey), "80a0")
+
+	s.Run("Disabled", func() {
+		cfg := New()
+		Load(cfg, portKey, 3000)
+		s.Equal(3000, cfg.Int(portKey))
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("Enabled", func() {
+		cfg := New()
+		cfg.SetStrict(true)
+		Load(cfg, portKey, 3000)
+		s.Equal(3000, cfg.Int(portKey))
+		s.ErrorIs(cfg.Err(), ErrInvalidVariable)
+	})
+
+	s.Run("EnabledAfterLoad", func() {
+		cfg := New()
+		Load(cfg, portKey, 3000)
+		s.Require().NoError(cfg.Err())
+		cfg.SetStrict(true)
+		s.ErrorIs(cfg.Err(), ErrInvalidVariable)
+		cfg.SetStrict(false)
+		s.NoError(cfg.Err())
+	})
+}
+
+func (s *StrictSuite) TestMergeKeepsErrors() {
+	portKey := Variable[int]("STRICT_MERGE_PORT")
+	s.setEnvVar(string(portKey), "80a0")
+
+	cfg1 := New()
+	s.Error(LoadStrict(cfg1, portKey, 3000))
+	cfg2 := New()
+
+	merged := Merge(cfg1, cfg2)
+	s.ErrorIs(merged.Err(), ErrInvalidVariable)
+}
+
+// --- Test Methods for RequiredSuite ---
+
+func (s *RequiredSuite) setEnvVar(key string, value string) {
+	s.Require().NoError(os.Setenv(key, value))
+	s.T().Cleanup(func() {
+		os.Unsetenv(key)
+	})
+}
+
+func (s *RequiredSuite) TestLoadRequired() {
+	apiKey := Variable[string]("REQUIRED_API_KEY")
+	portKey := Variable[int]("REQUIRED_PORT")
+	hostKey := Variable[string]("REQUIRED_HOST")
+
+	s.Run("Set", func() {
+		cfg := New()
+		s.setEnvVar(string(apiKey), "secret")
+		s.Require().NoError(LoadRequired(cfg, apiKey))
+		s.Equal("secret", cfg.String(apiKey))
+		s.NoError(cfg.Exists(apiKey))
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("Unset", func() {
+		cfg := New()
+		err := LoadRequired(cfg, apiKey)
+		s.Require().Error(err)
+		s.ErrorIs(err, ErrMissingVariable)
+
+		var missingErr MissingVariableError
+		s.Require().ErrorAs(err, &missingErr)
+		s.Equal([]string{string(apiKey)}, missingErr.Keys)
+		s.Error(cfg.Exists(apiKey), "Nothing should be registered for a missing required variable")
+	})
+
+	s.Run("Invalid", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "80a0")
+		err := LoadRequired(cfg, portKey)
+		s.Require().Error(err)
+
+		var parseErr ParseError
+		s.Require().ErrorAs(err, &parseErr)
+		s.Equal(string(portKey), parseErr.Key)
+		s.ErrorIs(cfg.Err(), ErrInvalidVariable)
+		s.Error(cfg.Exists(portKey))
+	})
+
+	s.Run("AfterLoad", func() {
+		cfg := New()
+		Load(cfg, apiKey, "fallback")
+		s.ErrorIs(LoadRequired(cfg, apiKey), ErrMissingVariable, "A required declaration should replace a fallback")
+		s.ErrorIs(cfg.Err(), ErrMissingVariable)
+		s.Error(cfg.Exists(apiKey))
+
+		doc := cfg.Reference()
+		s.Require().Len(doc, 1)
+		s.True(doc[0].Required)
+
+		s.setEnvVar(string(apiKey), "secret")
+		Load(cfg, apiKey, "fallback")
+		s.NoError(LoadRequired(cfg, apiKey))
+		s.Equal("secret", cfg.String(apiKey))
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("AlreadyRegistered", func() {
+		cfg := New()
+		Write(cfg, map[Variable[string]]string{apiKey: "written"})
+		s.Require().NoError(LoadRequired(cfg, apiKey))
+		s.Equal("written", cfg.String(apiKey))
+	})
+
+	s.Run("AggregatesMissingKeys", func() {
+		cfg := New()
+		s.Error(LoadRequired(cfg, apiKey))
+		s.Error(LoadRequired(cfg, hostKey))
+		s.Error(LoadRequired(cfg, apiKey), "Loading the same key twice should not report it twice")
+
+		err := cfg.Err()
+		s.Require().Error(err)
+		s.ErrorIs(err, ErrMissingVariable)
+
+		var missingErr MissingVariableError
+		s.Require().ErrorAs(err, &missingErr)
+		s.Equal([]string{string(apiKey), string(hostKey)}, missingErr.Keys)
+		s.Equal("missing configuration variables: REQUIRED_API_KEY, REQUIRED_HOST", err.Error())
+	})
+
+	s.Run("WriteSatisfiesRequirement", func() {
+		cfg := New()
+		s.Error(LoadRequired(cfg, apiKey))
+		Write(cfg, map[Variable[string]]string{apiKey: "written"})
+		s.NoError(cfg.Err())
+	})
+
+	s.Run("JoinsWithParseErrors", func() {
+		cfg := New()
+		s.setEnvVar(string(portKey), "80a0")
+		s.Error(LoadRequired(cfg, apiKey))
+		s.Error(LoadRequired(cfg, portKey))
+
+		err := cfg.Err()
+		s.ErrorIs(err, ErrMissingVariable)
+		s.ErrorIs(err, ErrInvalidVariable)
+	})
+
+	s.Run("Merge", func() {
+		cfg1 := New()
+		s.Error(LoadRequired(cfg1, apiKey))
+		s.Error(LoadRequired(cfg1, hostKey))
+		cfg2 := New()
+		Write(cfg2, map[Variable[string]]string{apiKey: "written"})
+
+		var missingErr MissingVariableError
+		s.Require().ErrorAs(Merge(cfg1, cfg2).Err(), &missingErr)
+		s.Equal([]string{string(hostKey)}, missingErr.Keys)
+	})
+}
+
 // --- Test Methods for FormatKeysSuite ---
 
 func (s *FormatKeysSuite) TestFormatKeys() {
@@ -1090,29 +1546,49 @@ func (s *FallbackSuite) TestFallbackBool() {
 	}
 }
 
+// registered returns the variables of type T that are registered in cfg, along with their values.
+func registered[T any](cfg *Config) map[Variable[T]]T {
+	values := make(map[Variable[T]]T)
+	for key, value := range cfg.registry().values {
+		if k, ok := key.(Variable[T]); ok {
+			values[k] = value.(T)
+		}
+	}
+	return values
+}
+
 // --- Test Methods for MergeSuite ---
 
 func (s *MergeSuite) TestMergeEmpty() {
 	cfg := Merge()
 	s.Require().NotNil(cfg, "Merged config should not be nil")
 
-	s.Empty(cfg.regString, "RegString should be empty")
-	s.Empty(cfg.regInt, "RegInt should be empty")
-	s.Empty(cfg.regInt8, "RegInt8 should be empty")
-	s.Empty(cfg.regInt16, "RegInt16 should be empty")
-	s.Empty(cfg.regInt32, "RegInt32 should be empty")
-	s.Empty(cfg.regInt64, "RegInt64 should be empty")
-	s.Empty(cfg.regUint, "RegUint should be empty")
-	s.Empty(cfg.regUint8, "RegUint8 should be empty")
-	s.Empty(cfg.regUint16, "RegUint16 should be empty")
-	s.Empty(cfg.regUint32, "RegUint32 should be empty")
-	s.Empty(cfg.regUint64, "RegUint64 should be empty")
-	s.Empty(cfg.regUintptr, "RegUintptr should be empty")
-	s.Empty(cfg.regBytes, "RegBytes should be empty")
-	s.Empty(cfg.regRunes, "RegRunes should be empty")
-	s.Empty(cfg.regFloat32, "RegFloat32 should be empty")
-	s.Empty(cfg.regFloat64, "RegFloat64 should be empty")
-	s.Empty(cfg.regBool, "RegBool should be empty")
+	s.Empty(registered[string](cfg), "RegString should be empty")
+	s.Empty(registered[int](cfg), "RegInt should be empty")
+	s.Empty(registered[int8](cfg), "RegInt8 should be empty")
+	s.Empty(registered[int16](cfg), "RegInt16 should be empty")
+	s.Empty(registered[int32](cfg), "RegInt32 should be empty")
+	s.Empty(registered[int64](cfg), "RegInt64 should be empty")
+	s.Empty(registered[uint](cfg), "RegUint should be empty")
+	s.Empty(registered[uint8](cfg), "RegUint8 should be empty")
+	s.Empty(registered[uint16](cfg), "RegUint16 should be empty")
+	s.Empty(registered[uint32](cfg), "RegUint32 should be empty")
+	s.Empty(registered[uint64](cfg), "RegUint64 should be empty")
+	s.Empty(registered[uintptr](cfg), "RegUintptr should be empty")
+	s.Empty(registered[[]byte](cfg), "RegBytes should be empty")
+	s.Empty(registered[[]rune](cfg), "RegRunes should be empty")
+	s.Empty(registered[float32](cfg), "RegFloat32 should be empty")
+	s.Empty(registered[float64](cfg), "RegFloat64 should be empty")
+	s.Empty(registered[bool](cfg), "RegBool should be empty")
+	s.Empty(registered[time.Duration](cfg), "RegDuration should be empty")
+	s.Empty(registered[[]string](cfg), "RegStrings should be empty")
+	s.Empty(registered[[]int](cfg), "RegInts should be empty")
+	s.Empty(registered[[]float64](cfg), "RegFloat64s should be empty")
+	s.Empty(registered[[]bool](cfg), "RegBools should be empty")
+	s.Empty(registered[map[string]string](cfg), "RegStringMap should be empty")
+	s.Empty(registered[map[string]int](cfg), "RegIntMap should be empty")
+	s.Empty(registered[map[string]int64](cfg), "RegInt64Map should be empty")
+	s.Empty(registered[map[string]float64](cfg), "RegFloat64Map should be empty")
 }
 
 func (s *MergeSuite) TestMergeSingle() {
@@ -1128,8 +1604,8 @@ func (s *MergeSuite) TestMergeSingle() {
 
 	s.Equal("value1", mergedCfg.String(keyStr))
 	s.Equal(123, mergedCfg.Int(keyInt))
-	s.Len(mergedCfg.regString, 1)
-	s.Len(mergedCfg.regInt, 1)
+	s.Len(registered[string](mergedCfg), 1)
+	s.Len(registered[int](mergedCfg), 1)
 }
 
 func (s *MergeSuite) TestMergeTwoDistinct() {
@@ -1147,10 +1623,10 @@ func (s *MergeSuite) TestMergeTwoDistinct() {
 	s.Equal("value1", mergedCfg.String(keyStr1))
 	s.Equal(100, mergedCfg.Int(keyInt1))
 
-	s.Len(mergedCfg.regString, 1, "RegString should have 1 entry")
-	s.Equal("value1", mergedCfg.regString[keyStr1])
-	s.Len(mergedCfg.regInt, 1, "RegInt should have 1 entry")
-	s.Equal(100, mergedCfg.regInt[keyInt1])
+	s.Len(registered[string](mergedCfg), 1, "RegString should have 1 entry")
+	s.Equal("value1", registered[string](mergedCfg)[keyStr1])
+	s.Len(registered[int](mergedCfg), 1, "RegInt should have 1 entry")
+	s.Equal(100, registered[int](mergedCfg)[keyInt1])
 }
 
 func (s *MergeSuite) TestMergeTwoOverride() {
@@ -1172,12 +1648,12 @@ func (s *MergeSuite) TestMergeTwoOverride() {
 	s.Equal(111, mergedCfg.Int(keyInt))                   // From cfg1
 	s.True(mergedCfg.Bool(keyBool))                       // From cfg2
 
-	s.Len(mergedCfg.regString, 1)
-	s.Equal("overridden_value", mergedCfg.regString[keyStr])
-	s.Len(mergedCfg.regInt, 1)
-	s.Equal(111, mergedCfg.regInt[keyInt])
-	s.Len(mergedCfg.regBool, 1)
-	s.True(mergedCfg.regBool[keyBool])
+	s.Len(registered[string](mergedCfg), 1)
+	s.Equal("overridden_value", registered[string](mergedCfg)[keyStr])
+	s.Len(registered[int](mergedCfg), 1)
+	s.Equal(111, registered[int](mergedCfg)[keyInt])
+	s.Len(registered[bool](mergedCfg), 1)
+	s.True(registered[bool](mergedCfg)[keyBool])
 }
 
 func (s *MergeSuite) TestMergeMultiple() {
@@ -1205,9 +1681,9 @@ func (s *MergeSuite) TestMergeMultiple() {
 	s.Equal(222, mergedCfg.Int(keyInt1))
 	s.True(mergedCfg.Bool(keyBool1))
 
-	s.Len(mergedCfg.regString, 2) // S1, SHARED_KEY
-	s.Len(mergedCfg.regInt, 1)    // I1
-	s.Len(mergedCfg.regBool, 1)   // B1
+	s.Len(registered[string](mergedCfg), 2) // S1, SHARED_KEY
+	s.Len(registered[int](mergedCfg), 1)    // I1
+	s.Len(registered[bool](mergedCfg), 1)   // B1
 }
 
 func (s *MergeSuite) TestMergeAllTypes() {
@@ -1249,6 +1725,24 @@ func (s *MergeSuite) TestMergeAllTypes() {
 	vFloat64_2 := 20.5
 	kBool := Variable[bool]("TYPE_BOOL")
 	vBool1, vBool2 := false, true // Bool1 is in cfg1, Bool2 will override
+	kDuration := Variable[time.Duration]("TYPE_DURATION")
+	vDuration1, vDuration2 := time.Second, time.Minute
+	kStrings := Variable[[]string]("TYPE_STRINGS")
+	vStrings1 := []string{"a", "b"}
+	kInts := Variable[[]int]("TYPE_INTS")
+	vInts2 := []int{1, 2}
+	kFloat64s := Variable[[]float64]("TYPE_FLOAT64S")
+	vFloat64s2 := []float64{1.5}
+	kBools := Variable[[]bool]("TYPE_BOOLS")
+	vBools1 := []bool{true}
+	kStringMap := Variable[map[string]string]("TYPE_STRING_MAP")
+	vStringMap1, vStringMap2 := map[string]string{"a": "1"}, map[string]string{"b": "2"}
+	kIntMap := Variable[map[string]int]("TYPE_INT_MAP")
+	vIntMap1 := map[string]int{"a": 1}
+	kInt64Map := Variable[map[string]int64]("TYPE_INT64_MAP")
+	vInt64Map2 := map[string]int64{"a": 1}
+	kFloat64Map := Variable[map[string]float64]("TYPE_FLOAT64_MAP")
+	vFloat64Map2 := map[string]float64{"a": 0.5}
 
 	// Load into cfg1 (these will be overridden or kept if not in cfg2)
 	Load(cfg1, kStr, vStr1)
@@ -1264,7 +1758,12 @@ func (s *MergeSuite) TestMergeAllTypes() {
 	Load(cfg1, kUint32, vUint32_1)
 	Load(cfg1, kUint64, vUint64_1)
 	Load(cfg1, kUintptr, vUintptr1)
-	Load(cfg1, kBool, vBool1) // Will be overridden
+	Load(cfg1, kBool, vBool1)         // Will be overridden
+	Load(cfg1, kDuration, vDuration1) // Will be overridden
+	Load(cfg1, kStrings, vStrings1)
+	Load(cfg1, kBools, vBools1)
+	Load(cfg1, kStringMap, vStringMap1) // Will be overridden
+	Load(cfg1, kIntMap, vIntMap1)
 
 	// Load into cfg2 (these will override cfg1 or be new)
 	Load(cfg2, kStr, vStr2)      // Override
@@ -1278,7 +1777,13 @@ func (s *MergeSuite) TestMergeAllTypes() {
 	Load(cfg2, kRunes, vRunes2)
 	Load(cfg2, kFloat32, vFloat32_2)
 	Load(cfg2, kFloat64, vFloat64_2)
-	Load(cfg2, kBool, vBool2) // Override
+	Load(cfg2, kBool, vBool2)         // Override
+	Load(cfg2, kDuration, vDuration2) // Override
+	Load(cfg2, kInts, vInts2)
+	Load(cfg2, kFloat64s, vFloat64s2)
+	Load(cfg2, kStringMap, vStringMap2) // Override
+	Load(cfg2, kInt64Map, vInt64Map2)
+	Load(cfg2, kFloat64Map, vFloat64Map2)
 
 	mergedCfg := Merge(cfg1, cfg2)
 	s.Require().NotNil(mergedCfg)
@@ -1291,6 +1796,8 @@ func (s *MergeSuite) TestMergeAllTypes() {
 	s.Equal(vInt32_2, mergedCfg.Int32(kInt32))
 	s.Equal(vInt64_2, mergedCfg.Int64(kInt64))
 	s.Equal(vBool2, mergedCfg.Bool(kBool))
+	s.Equal(vDuration2, mergedCfg.Duration(kDuration))
+	s.Equal(vStringMap2, mergedCfg.StringMap(kStringMap))
 
 	// Assertions for values only in cfg1
 	s.Equal(vUint1, mergedCfg.Uint(kUint))
@@ -1299,30 +1806,46 @@ func (s *MergeSuite) TestMergeAllTypes() {
 	s.Equal(vUint32_1, mergedCfg.Uint32(kUint32))
 	s.Equal(vUint64_1, mergedCfg.Uint64(kUint64))
 	s.Equal(vUintptr1, mergedCfg.Uintptr(kUintptr))
+	s.Equal(vStrings1, mergedCfg.Strings(kStrings))
+	s.Equal(vBools1, mergedCfg.Bools(kBools))
+	s.Equal(vIntMap1, mergedCfg.IntMap(kIntMap))
 
 	// Assertions for values only in cfg2
 	s.Equal(vBytes2, mergedCfg.Bytes(kBytes))
 	s.Equal(vRunes2, mergedCfg.Runes(kRunes))
 	s.Equal(vFloat32_2, mergedCfg.Float32(kFloat32))
 	s.Equal(vFloat64_2, mergedCfg.Float64(kFloat64))
-
-	s.Len(mergedCfg.regString, 1)
-	s.Len(mergedCfg.regInt, 1)
-	s.Len(mergedCfg.regInt8, 1)
-	s.Len(mergedCfg.regInt16, 1)
-	s.Len(mergedCfg.regInt32, 1)
-	s.Len(mergedCfg.regInt64, 1)
-	s.Len(mergedCfg.regUint, 1)
-	s.Len(mergedCfg.regUint8, 1)
-	s.Len(mergedCfg.regUint16, 1)
-	s.Len(mergedCfg.regUint32, 1)
-	s.Len(mergedCfg.regUint64, 1)
-	s.Len(mergedCfg.regUintptr, 1)
-	s.Len(mergedCfg.regBytes, 1)
-	s.Len(mergedCfg.regRunes, 1)
-	s.Len(mergedCfg.regFloat32, 1)
-	s.Len(mergedCfg.regFloat64, 1)
-	s.Len(mergedCfg.regBool, 1)
+	s.Equal(vInts2, mergedCfg.Ints(kInts))
+	s.Equal(vFloat64s2, mergedCfg.Float64s(kFloat64s))
+	s.Equal(vInt64Map2, mergedCfg.Int64Map(kInt64Map))
+	s.Equal(vFloat64Map2, mergedCfg.Float64Map(kFloat64Map))
+
+	s.Len(registered[string](mergedCfg), 1)
+	s.Len(registered[int](mergedCfg), 1)
+	s.Len(registered[int8](mergedCfg), 1)
+	s.Len(registered[int16](mergedCfg), 1)
+	s.Len(registered[int32](mergedCfg), 1)
+	s.Len(registered[int64](mergedCfg), 1)
+	s.Len(registered[uint](mergedCfg), 1)
+	s.Len(registered[uint8](mergedCfg), 1)
+	s.Len(registered[uint16](mergedCfg), 1)
+	s.Len(registered[uint32](mergedCfg), 1)
+	s.Len(registered[uint64](mergedCfg), 1)
+	s.Len(registered[uintptr](mergedCfg), 1)
+	s.Len(registered[[]byte](mergedCfg), 1)
+	s.Len(registered[[]rune](mergedCfg), 1)
+	s.Len(registered[float32](mergedCfg), 1)
+	s.Len(registered[float64](mergedCfg), 1)
+	s.Len(registered[bool](mergedCfg), 1)
+	s.Len(registered[time.Duration](mergedCfg), 1)
+	s.Len(registered[[]string](mergedCfg), 1)
+	s.Len(registered[[]int](mergedCfg), 1)
+	s.Len(registered[[]float64](mergedCfg), 1)
+	s.Len(registered[[]bool](mergedCfg), 1)
+	s.Len(registered[map[string]string](mergedCfg), 1)
+	s.Len(registered[map[string]int](mergedCfg), 1)
+	s.Len(registered[map[string]int64](mergedCfg), 1)
+	s.Len(registered[map[string]float64](mergedCfg), 1)
 }
 
 // --- Test Methods for CheckKeySuite ---
@@ -1334,56 +1857,62 @@ func (s *CheckKeySuite) TestCheckKey() {
 	intKey := Variable[int]("MY_INT")
 	boolKey := Variable[bool]("MY_BOOL")
 	float32Key := Variable[float32]("MY_FLOAT32")
+	durationKey := Variable[time.Duration]("MY_DURATION")
 
 	missingStrKey := Variable[string]("MISSING_STRING")
 	missingIntKey := Variable[int]("MISSING_INT")
 	uintptrKey := Variable[uintptr]("MY_UINTPTR_UNINIT_MAP_SCENARIO")
 
-	cfg.regString[strKey] = "value"
-	cfg.regInt[intKey] = 123
-	cfg.regBool[boolKey] = true
-	cfg.regFloat32[float32Key] = 3.14
+	cfg.registry().values[strKey] = "value"
+	cfg.registry().values[intKey] = 123
+	cfg.registry().values[boolKey] = true
+	cfg.registry().values[float32Key] = float32(3.14)
+	cfg.registry().values[durationKey] = time.Second
 
 	s.Run("ExistingKeys", func() {
-		name, exists := cfg.checkKey(strKey)
+		name, exists := cfg.hasKey(strKey)
 		assert.True(s.T(), exists)
 		assert.Equal(s.T(), string(strKey), name)
 
-		name, exists = cfg.checkKey(intKey)
+		name, exists = cfg.hasKey(intKey)
 		assert.True(s.T(), exists)
 		assert.Equal(s.T(), string(intKey), name)
 
-		name, exists = cfg.checkKey(boolKey)
+		name, exists = cfg.hasKey(boolKey)
 		assert.True(s.T(), exists)
 		assert.Equal(s.T(), string(boolKey), name)
 
-		name, exists = cfg.checkKey(float32Key)
+		name, exists = cfg.hasKey(float32Key)
 		assert.True(s.T(), exists)
 		assert.Equal(s.T(), string(float32Key), name)
+
+		name, exists = cfg.hasKey(durationKey)
+		assert.True(s.T(), exists)
+		assert.Equal(s.T(), string(durationKey), name)
 	})
 
 	s.Run("MissingKeys", func() {
-		name, exists := cfg.checkKey(missingStrKey)
+		name, exists := cfg.hasKey(missingStrKey)
 		assert.False(s.T(), exists)
 		assert.Equal(s.T(), string(missingStrKey), name)
 
-		name, exists = cfg.checkKey(missingIntKey)
+		name, exists = cfg.hasKey(missingIntKey)
 		assert.False(s.T(), exists)
 		assert.Equal(s.T(), string(missingIntKey), name)
 
-		name, exists = cfg.checkKey(uintptrKey)
+		name, exists = cfg.hasKey(uintptrKey)
 		assert.False(s.T(), exists)
 		assert.Equal(s.T(), string(uintptrKey), name)
 	})
 
 	s.Run("DifferentKeyTypeSameName", func() {
 		diffTypeSameNameKey := Variable[string]("MY_INT")
-		name, exists := cfg.checkKey(diffTypeSameNameKey)
+		name, exists := cfg.hasKey(diffTypeSameNameKey)
 		assert.False(s.T(), exists)
 		assert.Equal(s.T(), string(diffTypeSameNameKey), name)
 
 		diffTypeSameNameKey2 := Variable[int]("MY_STRING")
-		name, exists = cfg.checkKey(diffTypeSameNameKey2)
+		name, exists = cfg.hasKey(diffTypeSameNameKey2)
 		assert.False(s.T(), exists)
 		assert.Equal(s.T(), string(diffTypeSameNameKey2), name)
 	})
@@ -1397,6 +1926,8 @@ func TestConfiguraSuite(t *testing.T) {
 	suite.Run(t, new(FormatKeysSuite))
 	suite.Run(t, new(CheckKeySuite))
 	suite.Run(t, new(ExistsSuite))
+	suite.Run(t, new(StrictSuite))
+	suite.Run(t, new(RequiredSuite))
 	suite.Run(t, new(FallbackSuite))
 	suite.Run(t, new(MergeSuite))
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -157,6 +158,69 @@ func (s *ThreadSafetySuite) TestConcurrentAccess() {
 				_ = s.config.Bool(key)
 			},
 		},
+		{
+			name: "Duration",
+			action: func(i int) {
+				key := Variable[time.Duration](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Duration(key)
+			},
+		},
+		{
+			name: "Strings",
+			action: func(i int) {
+				key := Variable[[]string](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Strings(key)
+			},
+		},
+		{
+			name: "Ints",
+			action: func(i int) {
+				key := Variable[[]int](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Ints(key)
+			},
+		},
+		{
+			name: "Float64s",
+			action: func(i int) {
+				key := Variable[[]float64](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Float64s(key)
+			},
+		},
+		{
+			name: "Bools",
+			action: func(i int) {
+				key := Variable[[]bool](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Bools(key)
+			},
+		},
+		{
+			name: "StringMap",
+			action: func(i int) {
+				key := Variable[map[string]string](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.StringMap(key)
+			},
+		},
+		{
+			name: "IntMap",
+			action: func(i int) {
+				key := Variable[map[string]int](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.IntMap(key)
+			},
+		},
+		{
+			name: "Int64Map",
+			action: func(i int) {
+				key := Variable[map[string]int64](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Int64Map(key)
+			},
+		},
+		{
+			name: "Float64Map",
+			action: func(i int) {
+				key := Variable[map[string]float64](fmt.Sprintf("KEY_%d", i))
+				_ = s.config.Float64Map(key)
+			},
+		},
 	}
 
 	const numGoroutines = 100
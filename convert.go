@@ -0,0 +1,163 @@
+package configura
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// convert converts a structured value, as held by a ValueSource, into the type of key. Strings are parsed in the same
+// way as raw values, so a string in a file behaves like the same string in the environment. Any other value must match
+// the kind of the type: a boolean for bool, a number for the numeric types, an array for lists and an object for maps.
+// Booleans and numbers are also accepted for string and for types registered with RegisterType. The caller must hold
+// the lock.
+func convert[T any](cfg *Config, key Variable[T], data any) (T, error) {
+	if s, ok := data.(string); ok {
+		return parse(cfg, key, s)
+	}
+
+	var zero T
+	def, ok := lookupType(reflect.TypeFor[T]())
+	if !ok {
+		return zero, fmt.Errorf("%w %s", ErrUnsupportedType, typeName(key))
+	}
+	if def.convert == nil {
+		return zero, mismatch("a string", data)
+	}
+
+	value, err := def.convert(cfg, data)
+	if err != nil {
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// convertString converts a boolean or a number into its text.
+func convertString(_ *Config, data any) (string, error) {
+	if _, ok := data.(bool); ok {
+		return formatValue(data), nil
+	}
+	if text, err := numberText(data); err == nil {
+		return text, nil
+	}
+	return "", mismatch("a string", data)
+}
+
+// convertBool converts a boolean.
+func convertBool(_ *Config, data any) (bool, error) {
+	if b, ok := data.(bool); ok {
+		return b, nil
+	}
+	return false, mismatch("a boolean", data)
+}
+
+// convertNumber converts a number into a numeric type, through its decimal form so that the range of T is checked.
+func convertNumber[T any](cfg *Config, data any) (T, error) {
+	text, err := numberText(data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return parse(cfg, Variable[T](""), text)
+}
+
+// convertText converts a boolean or a number into T by parsing its text.
+func convertText[T any](cfg *Config, data any) (T, error) {
+	text, err := convertString(cfg, data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return parse(cfg, Variable[T](""), text)
+}
+
+// convertList converts an array into a list, element by element.
+func convertList[E any](cfg *Config, data any) ([]E, error) {
+	items, ok := data.([]any)
+	if !ok {
+		return nil, mismatch("an array", data)
+	}
+
+	values := make([]E, len(items))
+	for i, item := range items {
+		value, err := convert(cfg, Variable[E](""), item)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// convertMap converts an object into a map, entry by entry.
+func convertMap[E any](cfg *Config, data any) (map[string]E, error) {
+	entries, ok := data.(map[string]any)
+	if !ok {
+		return nil, mismatch("an object", data)
+	}
+
+	values := make(map[string]E, len(entries))
+	for k, entry := range entries {
+		value, err := convert(cfg, Variable[E](""), entry)
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", k, err)
+		}
+		values[k] = value
+	}
+
+	return values, nil
+}
+
+// numberText returns the decimal form of a number.
+func numberText(data any) (string, error) {
+	switch v := data.(type) {
+	case json.Number:
+		return v.String(), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", mismatch("a number", data)
+}
+
+// mismatch returns an ErrTypeMismatch error for data, which should have been of the expected kind.
+func mismatch(expected string, data any) error {
+	return fmt.Errorf("%w: expected %s, found %s", ErrTypeMismatch, expected, kindOf(data))
+}
+
+// kindOf describes the kind of a structured value for error messages.
+func kindOf(data any) string {
+	switch data.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	if _, err := numberText(data); err == nil {
+		return "a number"
+	}
+	return fmt.Sprintf("a %T", data)
+}
+
+// formatValue returns the string form of a structured value. Strings are returned as is, and every other value is
+// formatted as JSON.
+func formatValue(data any) string {
+	if s, ok := data.(string); ok {
+		return s
+	}
+	if b, err := json.Marshal(data); err == nil {
+		return string(b)
+	}
+	return fmt.Sprint(data)
+}
@@ -0,0 +1,89 @@
+package configura
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	cfg := New()
+
+	t.Run("Valid", func(t *testing.T) {
+		assertConverted(t, cfg, Variable[string]("K"), "text", "text")
+		assertConverted(t, cfg, Variable[string]("K"), json.Number("8080"), "8080")
+		assertConverted(t, cfg, Variable[string]("K"), true, "true")
+		assertConverted(t, cfg, Variable[int]("K"), json.Number("-42"), -42)
+		assertConverted(t, cfg, Variable[int]("K"), "42", 42)
+		assertConverted(t, cfg, Variable[int8]("K"), 8, int8(8))
+		assertConverted(t, cfg, Variable[int64]("K"), int64(64), int64(64))
+		assertConverted(t, cfg, Variable[uint16]("K"), uint64(16), uint16(16))
+		assertConverted(t, cfg, Variable[float32]("K"), 1.5, float32(1.5))
+		assertConverted(t, cfg, Variable[float64]("K"), json.Number("2.5e2"), 250.0)
+		assertConverted(t, cfg, Variable[bool]("K"), true, true)
+		assertConverted(t, cfg, Variable[bool]("K"), "false", false)
+		assertConverted(t, cfg, Variable[time.Duration]("K"), "1m", time.Minute)
+		assertConverted(t, cfg, Variable[[]byte]("K"), "bytes", []byte("bytes"))
+		assertConverted(t, cfg, Variable[[]string]("K"), []any{"a", json.Number("1")}, []string{"a", "1"})
+		assertConverted(t, cfg, Variable[[]string]("K"), "a,b", []string{"a", "b"})
+		assertConverted(t, cfg, Variable[[]int]("K"), []any{json.Number("1"), "2"}, []int{1, 2})
+		assertConverted(t, cfg, Variable[[]float64]("K"), []any{0.5}, []float64{0.5})
+		assertConverted(t, cfg, Variable[[]bool]("K"), []any{true, false}, []bool{true, false})
+		assertConverted(t, cfg, Variable[[]int]("K"), []any{}, []int{})
+		assertConverted(t, cfg, Variable[map[string]string]("K"), map[string]any{"a": "b"}, map[string]string{"a": "b"})
+		assertConverted(t, cfg, Variable[map[string]int]("K"), map[string]any{"a": json.Number("1")}, map[string]int{"a": 1})
+		assertConverted(t, cfg, Variable[map[string]int64]("K"), map[string]any{"a": 1}, map[string]int64{"a": 1})
+		assertConverted(t, cfg, Variable[map[string]float64]("K"), map[string]any{"a": 0.5}, map[string]float64{"a": 0.5})
+	})
+
+	t.Run("Mismatched", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			conv func() error
+			msg  string
+		}{
+			{"BoolAsInt", func() error { _, err := convert(cfg, Variable[int]("K"), true); return err }, "mismatched type: expected a number, found a boolean"},
+			{"NumberAsBool", func() error { _, err := convert(cfg, Variable[bool]("K"), json.Number("1")); return err }, "mismatched type: expected a boolean, found a number"},
+			{"ArrayAsString", func() error { _, err := convert(cfg, Variable[string]("K"), []any{}); return err }, "mismatched type: expected a string, found an array"},
+			{"NumberAsDuration", func() error { _, err := convert(cfg, Variable[time.Duration]("K"), json.Number("30")); return err }, "mismatched type: expected a string, found a number"},
+			{"ObjectAsList", func() error { _, err := convert(cfg, Variable[[]int]("K"), map[string]any{}); return err }, "mismatched type: expected an array, found an object"},
+			{"ArrayAsMap", func() error { _, err := convert(cfg, Variable[map[string]int]("K"), []any{}); return err }, "mismatched type: expected an object, found an array"},
+			{"ListElement", func() error { _, err := convert(cfg, Variable[[]int]("K"), []any{json.Number("1"), true}); return err }, "element 1: mismatched type: expected a number, found a boolean"},
+			{"MapEntry", func() error {
+				_, err := convert(cfg, Variable[map[string]string]("K"), map[string]any{"a": []any{}})
+				return err
+			}, `entry "a": mismatched type: expected a string, found an array`},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.conv()
+				assert.ErrorIs(t, err, ErrTypeMismatch)
+				assert.EqualError(t, err, tc.msg)
+			})
+		}
+	})
+
+	t.Run("FractionalNumberAsInt", func(t *testing.T) {
+		_, err := convert(cfg, Variable[int]("K"), json.Number("1.5"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "text", formatValue("text"))
+	assert.Equal(t, "8080", formatValue(json.Number("8080")))
+	assert.Equal(t, "true", formatValue(true))
+	assert.Equal(t, `[1,"a"]`, formatValue([]any{1, "a"}))
+	assert.Equal(t, `{"a":1}`, formatValue(map[string]any{"a": 1}))
+}
+
+func assertConverted[T any](t *testing.T, cfg *Config, key Variable[T], data any, expected T) {
+	t.Helper()
+	value, err := convert(cfg, key, data)
+	require.NoError(t, err)
+	assert.Equal(t, expected, value)
+}
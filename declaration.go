@@ -0,0 +1,94 @@
+package configura
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// declaration describes a variable that was declared through Load, LoadStrict or LoadRequired.
+type declaration interface {
+	// name returns the name of the variable.
+	name() string
+	// typeName returns the Go type of the variable.
+	typeName() string
+	// supported reports whether the type of the variable is built in or registered with RegisterType.
+	supported() bool
+	// resolve registers the value of the variable from its sources, or its fallback, along with its origin, and
+	// records a failure to parse it. The caller must hold the write lock.
+	resolve(cfg *Config) *ParseError
+	// restore registers value as the value of the variable, or removes the variable if ok is false. The caller must
+	// hold the write lock.
+	restore(cfg *Config, value any, ok bool)
+	// fallbackValue returns the fallback of the variable, or false if the variable is required and has none.
+	fallbackValue() (any, bool)
+	// reported reports whether a failure to parse the variable is reported by Config.Err even when strict mode is
+	// disabled, because the variable was loaded with LoadStrict or LoadRequired.
+	reported() bool
+	// flag returns a flag that overrides the variable in cfg when it is set. The caller must hold the lock.
+	flag(cfg *Config) (usage string, value flag.Value)
+}
+
+// declared is the declaration of a Variable[T], along with the fallback and sources it was loaded with.
+type declared[T any] struct {
+	key      Variable[T]
+	fallback T
+	sources  []Source
+	strict   bool
+	required bool
+}
+
+func (d *declared[T]) name() string {
+	return string(d.key)
+}
+
+func (d *declared[T]) typeName() string {
+	return typeName(d.key)
+}
+
+func (d *declared[T]) fallbackValue() (any, bool) {
+	return d.fallback, !d.required
+}
+
+func (d *declared[T]) reported() bool {
+	return d.strict || d.required
+}
+
+func (d *declared[T]) supported() bool {
+	_, ok := lookupType(reflect.TypeFor[T]())
+	return ok
+}
+
+func (d *declared[T]) resolve(cfg *Config) *ParseError {
+	value, origin, ok, parseErr := resolve(cfg, d.key, d.sources)
+	switch {
+	case ok && parseErr == nil:
+		write(cfg, map[Variable[T]]T{d.key: value})
+		cfg.origins[d.key] = origin
+	case d.required:
+		cfg.remove(d.key)
+	default:
+		write(cfg, map[Variable[T]]T{d.key: d.fallback})
+		cfg.origins[d.key] = Origin{Kind: OriginFallback, Time: time.Now()}
+	}
+
+	cfg.parseErrors = slices.DeleteFunc(cfg.parseErrors, func(err ParseError) bool {
+		return err.Key == string(d.key) && err.Type == typeName(d.key)
+	})
+	// An unsupported type is reported by Config.Err for every declaration, whether or not it is set.
+	if parseErr != nil && !errors.Is(parseErr, ErrUnsupportedType) {
+		cfg.parseErrors = append(cfg.parseErrors, *parseErr)
+	}
+
+	return parseErr
+}
+
+func (d *declared[T]) restore(cfg *Config, value any, ok bool) {
+	if v, isT := value.(T); ok && isT {
+		write(cfg, map[Variable[T]]T{d.key: v})
+	} else {
+		cfg.remove(d.key)
+	}
+}
@@ -0,0 +1,94 @@
+package configura
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// KeyMapper maps the path of a value within a structured document, such as []string{"database", "url"}, to the name of
+// the variable that it is loaded into. A document in which two values map to the same name, such as database.url and
+// DATABASE_URL with UpperSnakeCase, is rejected with an error that names both paths.
+type KeyMapper func(path []string) string
+
+// UpperSnakeCase is a KeyMapper in the style of environment variables. It joins the path with underscores and converts
+// it to upper case, replacing dots, dashes and spaces with underscores, so that database.url becomes DATABASE_URL.
+func UpperSnakeCase(path []string) string {
+	return strings.NewReplacer(".", "_", "-", "_", " ", "_").Replace(strings.ToUpper(strings.Join(path, "_")))
+}
+
+// DotPath is a KeyMapper that joins the path with dots, so that database.url stays database.url.
+func DotPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+// Document is a ValueSource that holds the values of a structured configuration document, such as a JSON file. Nested
+// objects are flattened: every value is stored under the name that a KeyMapper gives its path, and objects are also
+// stored as a whole so that they can be loaded into map variables.
+type Document struct {
+	values map[string]Value
+}
+
+// newDocument flattens root into a Document. location describes where the value at a path was found, for error
+// messages, and is prefixed with file unless it is empty. Two values whose paths map to the same name are reported as
+// an error, rather than one of them being picked at random.
+func newDocument(root map[string]any, mapper KeyMapper, location func(path []string) string, file string) (*Document, error) {
+	if mapper == nil {
+		mapper = UpperSnakeCase
+	}
+
+	d := &Document{values: make(map[string]Value)}
+	paths := make(map[string][]string)
+	var flatten func(path []string, object map[string]any) error
+	flatten = func(path []string, object map[string]any) error {
+		for _, k := range slices.Sorted(maps.Keys(object)) {
+			v := object[k]
+			p := append(path[:len(path):len(path)], k)
+			if v != nil {
+				name := mapper(p)
+				if other, ok := paths[name]; ok {
+					return fmt.Errorf("%s and %s both map to %s", location(other), location(p), name)
+				}
+				paths[name] = p
+				loc := location(p)
+				if file != "" {
+					loc = file + ": " + loc
+				}
+				d.values[name] = Value{Data: v, Location: loc}
+			}
+			if nested, ok := v.(map[string]any); ok {
+				if err := flatten(p, nested); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := flatten(nil, root); err != nil {
+		if file != "" {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Lookup returns the string form of the value stored under key. Strings are returned as is, and every other value is
+// formatted as JSON.
+func (d *Document) Lookup(key string) (string, bool) {
+	v, ok := d.values[key]
+	if !ok {
+		return "", false
+	}
+	return formatValue(v.Data), true
+}
+
+// LookupValue returns the value stored under key.
+func (d *Document) LookupValue(key string) (Value, bool) {
+	v, ok := d.values[key]
+	return v, ok
+}
+
+var _ ValueSource = (*Document)(nil)
@@ -0,0 +1,275 @@
+package configura
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// DotenvFile reads the .env file at path with ParseDotenv. The result can be passed to New or Load as a source, so the
+// values in the file never touch the environment of the process.
+func DotenvFile(path string) (MapSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values, err := ParseDotenv(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return values, nil
+}
+
+// ParseDotenv parses the KEY=value lines of a .env file. Blank lines and lines starting with # are skipped, and a line
+// may start with "export". Values can be
+//
+//   - unquoted, in which case they end at the line break or at a # preceded by whitespace, and are trimmed,
+//   - wrapped in single quotes or backticks, in which case they are taken literally and may span several lines,
+//   - wrapped in double quotes, in which case they may span several lines and \n, \r, \t, \", \\ and \$ are escapes.
+//
+// Unquoted and double quoted values expand ${VAR} and $VAR with the value of a variable defined earlier in the file,
+// or else in the environment, or else an empty string.
+func ParseDotenv(r io.Reader) (MapSource, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{
+		src:    strings.ReplaceAll(string(data), "\r\n", "\n"),
+		line:   1,
+		values: MapSource{},
+	}
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return p.values, nil
+		}
+		line := p.line
+		if err := p.parseEntry(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// dotenvParser holds the state of ParseDotenv while it walks through the file.
+type dotenvParser struct {
+	src    string
+	pos    int
+	line   int
+	values MapSource
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+// skipSpace skips spaces and tabs, but not line breaks.
+func (p *dotenvParser) skipSpace() {
+	for c := p.peek(); c == ' ' || c == '\t'; c = p.peek() {
+		p.next()
+	}
+}
+
+// skipComment skips the rest of the line if it is a comment.
+func (p *dotenvParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for !p.eof() && p.peek() != '\n' {
+		p.next()
+	}
+}
+
+// skipBlank skips whitespace, line breaks and comment lines.
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\n':
+			p.next()
+		case '#':
+			p.skipComment()
+		default:
+			return
+		}
+	}
+}
+
+// parseEntry parses a single KEY=value entry, starting at the key.
+func (p *dotenvParser) parseEntry() error {
+	if rest := p.src[p.pos:]; strings.HasPrefix(rest, "export ") || strings.HasPrefix(rest, "export\t") {
+		p.pos += len("export")
+		p.skipSpace()
+	}
+
+	start := p.pos
+	for !p.eof() && isDotenvKeyChar(p.peek()) {
+		p.next()
+	}
+	key := p.src[start:p.pos]
+	if key == "" {
+		return fmt.Errorf("expected a variable name, found %q", p.peek())
+	}
+
+	p.skipSpace()
+	if p.peek() != '=' {
+		return fmt.Errorf("expected = after %s", key)
+	}
+	p.next()
+	p.skipSpace()
+
+	var value string
+	var err error
+	switch p.peek() {
+	case '\'', '`':
+		value, err = p.parseLiteral(p.next())
+	case '"':
+		p.next()
+		value, err = p.parseDoubleQuoted()
+	default:
+		value, err = p.parseUnquoted()
+	}
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	p.skipComment()
+	if !p.eof() && p.peek() != '\n' {
+		return fmt.Errorf("unexpected %q after the value of %s", p.peek(), key)
+	}
+
+	p.values[key] = value
+	return nil
+}
+
+// parseLiteral parses a value wrapped in single quotes or backticks, after the opening quote.
+func (p *dotenvParser) parseLiteral(quote byte) (string, error) {
+	end := strings.IndexByte(p.src[p.pos:], quote)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated %c quote", quote)
+	}
+
+	value := p.src[p.pos : p.pos+end]
+	for range end + 1 {
+		p.next()
+	}
+	return value, nil
+}
+
+// parseDoubleQuoted parses a value wrapped in double quotes, after the opening quote.
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	var b strings.Builder
+	for !p.eof() {
+		switch c := p.next(); c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", fmt.Errorf("unterminated \" quote")
+			}
+			switch e := p.next(); e {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+		case '$':
+			if err := p.expand(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", fmt.Errorf("unterminated \" quote")
+}
+
+// parseUnquoted parses a value that is not wrapped in quotes, which ends at the line break or at a comment.
+func (p *dotenvParser) parseUnquoted() (string, error) {
+	var b strings.Builder
+	for !p.eof() && p.peek() != '\n' {
+		c := p.next()
+		switch {
+		case c == '#' && (b.Len() == 0 || strings.HasSuffix(b.String(), " ") || strings.HasSuffix(b.String(), "\t")):
+			p.pos--
+			return strings.TrimRight(b.String(), " \t"), nil
+		case c == '$':
+			if err := p.expand(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return strings.TrimRight(b.String(), " \t"), nil
+}
+
+// expand writes the value of the variable referenced after a $ to b. A $ that is not followed by a variable name is
+// written as is.
+func (p *dotenvParser) expand(b *strings.Builder) error {
+	braced := p.peek() == '{'
+	if braced {
+		p.next()
+	}
+
+	start := p.pos
+	for !p.eof() && isDotenvNameChar(p.peek()) {
+		p.next()
+	}
+	name := p.src[start:p.pos]
+
+	if braced {
+		if p.peek() != '}' {
+			return fmt.Errorf("unterminated ${ in reference to %q", name)
+		}
+		p.next()
+	} else if name == "" {
+		b.WriteByte('$')
+		return nil
+	}
+
+	if value, ok := p.values[name]; ok {
+		b.WriteString(value)
+	} else {
+		b.WriteString(os.Getenv(name))
+	}
+	return nil
+}
+
+// isDotenvKeyChar reports whether c may be part of the key of an entry.
+func isDotenvKeyChar(c byte) bool {
+	return isDotenvNameChar(c) || c == '.' || c == '-'
+}
+
+// isDotenvNameChar reports whether c may be part of a variable name in a ${VAR} reference.
+func isDotenvNameChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
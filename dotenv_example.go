@@ -0,0 +1,100 @@
+package configura
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"slices"
+	"strings"
+)
+
+// DotenvExample writes a .env.example file to w, listing every variable that was declared through Load, LoadStrict or
+// LoadRequired, or that is registered in the configuration, ordered by name. Each variable is preceded by a comment
+// with its type, whether it is required or sensitive, and the metadata attached with Describe, and set to its fallback
+// in the syntax that ParseDotenv reads. Required variables, sensitive variables and variables that were never declared
+// are left empty, so the file never holds a secret and can be checked in alongside the code.
+func (c *Config) DotenvExample(w io.Writer) error {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+
+	type example struct {
+		key, typ, value string
+		required        bool
+		sensitive       bool
+		meta            Metadata
+	}
+	var examples []example
+	for key, decl := range c.sortedDeclarations() {
+		e := example{key: decl.name(), typ: decl.typeName(), sensitive: c.isSensitive(key)}
+		e.meta, _ = MetadataOf(key)
+		fallback, ok := decl.fallbackValue()
+		e.required = !ok
+		if ok && !e.sensitive {
+			e.value = format(c, fallback)
+		}
+		examples = append(examples, e)
+	}
+	for _, key := range c.keys() {
+		if _, ok := c.declarations[key]; !ok {
+			meta, _ := MetadataOf(key)
+			examples = append(examples, example{
+				key:       keyName(key),
+				typ:       typeName(key),
+				sensitive: c.isSensitive(key),
+				meta:      meta,
+			})
+		}
+	}
+	slices.SortStableFunc(examples, func(a, b example) int {
+		return cmp.Or(cmp.Compare(a.key, b.key), cmp.Compare(a.typ, b.typ))
+	})
+
+	bw := bufio.NewWriter(w)
+	for i, e := range examples {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		bw.WriteString("# " + e.key + " " + e.typ)
+		if e.required {
+			bw.WriteString(" (required)")
+		}
+		if e.sensitive {
+			bw.WriteString(" (sensitive)")
+		}
+		for _, line := range []struct{ label, text string }{
+			{"", e.meta.Description},
+			{"Example: ", e.meta.Example},
+			{"Owner: ", e.meta.Owner},
+			{"Deprecated: ", e.meta.Deprecated},
+		} {
+			if line.text == "" {
+				continue
+			}
+			// Every line of a text that spans several is commented, so that ParseDotenv can read the file back.
+			for i, text := range strings.Split(strings.ReplaceAll(line.text, "\r\n", "\n"), "\n") {
+				if i == 0 {
+					text = line.label + text
+				}
+				bw.WriteString("\n# " + text)
+			}
+		}
+		bw.WriteString("\n" + e.key + "=" + quoteDotenv(e.value) + "\n")
+	}
+	return bw.Flush()
+}
+
+// quoteDotenv returns value as it must be written in a .env file to be read back by ParseDotenv. Values that hold
+// whitespace, quotes, comments, escapes or expansions are wrapped in double quotes.
+func quoteDotenv(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n#\"'`\\$") {
+		return value
+	}
+	return `"` + strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	).Replace(value) + `"`
+}
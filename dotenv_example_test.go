@@ -0,0 +1,77 @@
+package configura
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDotenvExample(t *testing.T) {
+	portKey := Variable[int]("EXAMPLE_PORT")
+	timeoutKey := Variable[time.Duration]("EXAMPLE_TIMEOUT")
+	urlKey := Variable[string]("EXAMPLE_DATABASE_URL")
+	passwordKey := Variable[string]("EXAMPLE_PASSWORD")
+	greetingKey := Variable[string]("EXAMPLE_GREETING")
+	tagsKey := Variable[[]string]("EXAMPLE_TAGS")
+
+	cfg := New(MapSource{string(portKey): "8080"})
+	cfg.MarkSensitive(passwordKey)
+	Load(cfg, portKey, 3000)
+	Load(cfg, timeoutKey, 30*time.Second)
+	Load(cfg, passwordKey, "changeme")
+	Load(cfg, greetingKey, `say "hi" # to $USER`)
+	_ = LoadRequired(cfg, urlKey)
+	require.NoError(t, Write(cfg, map[Variable[[]string]][]string{tagsKey: {"a", "b"}}))
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.DotenvExample(&buf))
+	assert.Equal(t, `# EXAMPLE_DATABASE_URL string (required)
+EXAMPLE_DATABASE_URL=
+
+# EXAMPLE_GREETING string
+EXAMPLE_GREETING="say \"hi\" # to \$USER"
+
+# EXAMPLE_PASSWORD string (sensitive)
+EXAMPLE_PASSWORD=
+
+# EXAMPLE_PORT int
+EXAMPLE_PORT=3000
+
+# EXAMPLE_TAGS []string
+EXAMPLE_TAGS=
+
+# EXAMPLE_TIMEOUT time.Duration
+EXAMPLE_TIMEOUT=30s
+`, buf.String(), "The fallbacks should be listed rather than the values that were loaded")
+
+	values, err := ParseDotenv(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, `say "hi" # to $USER`, values[string(greetingKey)], "The file should read back with ParseDotenv")
+	assert.Equal(t, "30s", values[string(timeoutKey)])
+}
+
+func TestDotenvExampleMultilineMetadata(t *testing.T) {
+	hostKey := Describe(Variable[string]("EXAMPLE_MULTILINE_HOST"), Metadata{
+		Description: "line one\nline two",
+		Deprecated:  "use EXAMPLE_URL\r\ninstead",
+	})
+	cfg := New(MapSource{})
+	Load(cfg, hostKey, "localhost")
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.DotenvExample(&buf))
+	assert.Equal(t, `# EXAMPLE_MULTILINE_HOST string
+# line one
+# line two
+# Deprecated: use EXAMPLE_URL
+# instead
+EXAMPLE_MULTILINE_HOST=localhost
+`, buf.String())
+
+	values, err := ParseDotenv(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, MapSource{string(hostKey): "localhost"}, values)
+}
@@ -0,0 +1,104 @@
+package configura
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotenv(t *testing.T) {
+	t.Setenv("DOTENV_FROM_PROCESS", "process")
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected MapSource
+	}{
+		{"Empty", "", MapSource{}},
+		{"Simple", "A=1\nB=two", MapSource{"A": "1", "B": "two"}},
+		{"CommentsAndBlankLines", "# comment\n\nA=1\n  # indented comment\nB=2\n", MapSource{"A": "1", "B": "2"}},
+		{"Export", "export A=1\nexport\tB=2", MapSource{"A": "1", "B": "2"}},
+		{"WhitespaceAroundSeparator", "A = 1 \nB\t=\t2", MapSource{"A": "1", "B": "2"}},
+		{"EmptyValue", "A=\nB=", MapSource{"A": "", "B": ""}},
+		{"InlineComment", "A=1 # comment\nB=a#b\nC=#", MapSource{"A": "1", "B": "a#b", "C": ""}},
+		{"CarriageReturns", "A=1\r\nB=2\r\n", MapSource{"A": "1", "B": "2"}},
+		{"SingleQuoted", `A='  $B \n # "x" '`, MapSource{"A": `  $B \n # "x" `}},
+		{"Backticks", "A=`it's \"quoted\" $B`", MapSource{"A": `it's "quoted" $B`}},
+		{"DoubleQuoted", `A=" spaced # not a comment "`, MapSource{"A": " spaced # not a comment "}},
+		{"Escapes", `A="line\nnext\ttab \"q\" \\ \$B \x"`, MapSource{"A": "line\nnext\ttab \"q\" \\ $B \\x"}},
+		{"QuotedWithComment", `A="1" # comment`, MapSource{"A": "1"}},
+		{"MultilineDoubleQuoted", "A=\"first\nsecond\"\nB=3", MapSource{"A": "first\nsecond", "B": "3"}},
+		{"MultilineSingleQuoted", "A='first\nsecond'", MapSource{"A": "first\nsecond"}},
+		{"Interpolation", "HOST=db\nURL=postgres://${HOST}:5432/$NAME\nNAME=x", MapSource{"HOST": "db", "URL": "postgres://db:5432/", "NAME": "x"}},
+		{"InterpolationInDoubleQuotes", "HOST=db\nURL=\"${HOST}/$HOST\"", MapSource{"HOST": "db", "URL": "db/db"}},
+		{"InterpolationFromProcess", "A=${DOTENV_FROM_PROCESS}", MapSource{"A": "process"}},
+		{"LoneDollar", "A=cost $ 5$", MapSource{"A": "cost $ 5$"}},
+		{"RepeatedKeyKeepsLast", "A=1\nA=2", MapSource{"A": "2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := ParseDotenv(strings.NewReader(tc.input))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{"MissingSeparator", "A=1\nB", "line 2: expected = after B"},
+		{"MissingKey", "=1", `line 1: expected a variable name, found '='`},
+		{"UnterminatedDoubleQuote", "A=1\nB=\"open\n\nC=2", `line 2: unterminated " quote`},
+		{"UnterminatedSingleQuote", "A='open", "line 1: unterminated ' quote"},
+		{"TrailingCharacters", `A="1" 2`, `line 1: unexpected '2' after the value of A`},
+		{"UnterminatedReference", "A=${B", `line 1: unterminated ${ in reference to "B"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseDotenv(strings.NewReader(tc.input))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestDotenvFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(path, []byte("DOTENV_PORT=8080\nDOTENV_HOST=\"db.local\"\n"), 0o600))
+
+	t.Run("FeedsLoad", func(t *testing.T) {
+		src, err := DotenvFile(path)
+		require.NoError(t, err)
+
+		cfg := New(src)
+		Load(cfg, Variable[int]("DOTENV_PORT"), 3000)
+		Load(cfg, Variable[string]("DOTENV_HOST"), "localhost")
+		assert.Equal(t, 8080, cfg.Int("DOTENV_PORT"))
+		assert.Equal(t, "db.local", cfg.String("DOTENV_HOST"))
+
+		_, set := os.LookupEnv("DOTENV_PORT")
+		assert.False(t, set, "The process environment should not be modified")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := DotenvFile(filepath.Join(dir, "missing.env"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		invalid := filepath.Join(dir, "invalid.env")
+		require.NoError(t, os.WriteFile(invalid, []byte("A"), 0o600))
+		_, err := DotenvFile(invalid)
+		assert.EqualError(t, err, invalid+": line 1: expected = after A")
+	})
+}
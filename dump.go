@@ -0,0 +1,87 @@
+package configura
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Setting is a registered variable with its value, as listed by Config.Dump.
+type Setting struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	// Value is the value in its raw form, as it would be given to Load, or Redacted if the variable is sensitive.
+	Value     string `json:"value"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	// Origin describes where the value came from, such as "source env".
+	Origin string `json:"origin,omitempty"`
+	// Description and Deprecated are taken from the metadata attached with Describe.
+	Description string `json:"description,omitempty"`
+	Deprecated  string `json:"deprecated,omitempty"`
+}
+
+// Dump returns every variable that is registered in the configuration with its value, ordered by name and type. The
+// values of variables marked with MarkSensitive are Redacted.
+func (c *Config) Dump() []Setting {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+	return c.dump()
+}
+
+// DumpJSON writes the variables listed by Dump to w as an indented JSON array.
+func (c *Config) DumpJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.Dump())
+}
+
+// DumpText writes the variables listed by Dump to w as a table with the columns KEY, TYPE, VALUE, ORIGIN and
+// DESCRIPTION.
+func (c *Config) DumpText(w io.Writer) error {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tTYPE\tVALUE\tORIGIN\tDESCRIPTION")
+	for _, s := range c.Dump() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.Key, s.Type, s.Value, s.Origin, s.Description)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// The padding of the last columns is trimmed from variables without a description.
+	for line := range strings.Lines(buf.String()) {
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dump is the lock-free part of Dump. The caller must hold the lock.
+func (c *Config) dump() []Setting {
+	keys := c.keys()
+	settings := make([]Setting, len(keys))
+	for i, key := range keys {
+		s := Setting{Key: keyName(key), Type: typeName(key), Value: Redacted, Sensitive: c.isSensitive(key)}
+		if !s.Sensitive {
+			value, _ := c.value(key)
+			s.Value = format(c, value)
+		}
+		if origin, ok := c.origins[key]; ok {
+			s.Origin = origin.String()
+		}
+		if meta, ok := MetadataOf(key); ok {
+			s.Description, s.Deprecated = meta.Description, meta.Deprecated
+		}
+		settings[i] = s
+	}
+	slices.SortFunc(settings, func(a, b Setting) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return settings
+}
@@ -0,0 +1,60 @@
+package configura
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDump(t *testing.T) {
+	portKey := Variable[int]("DUMP_PORT")
+	timeoutKey := Variable[time.Duration]("DUMP_TIMEOUT")
+	tagsKey := Variable[[]string]("DUMP_TAGS")
+	secretKey := Variable[string]("DUMP_SECRET")
+	Describe(portKey, Metadata{Description: "Port of the HTTP server"})
+
+	newConfig := func() *Config {
+		cfg := New()
+		cfg.SetLayers(Layer{Name: "file", Source: MapSource{string(portKey): "8080", string(secretKey): "hunter2"}})
+		cfg.MarkSensitive(secretKey)
+		Load(cfg, portKey, 3000)
+		Load(cfg, timeoutKey, 30*time.Second)
+		Load(cfg, secretKey, "")
+		require.NoError(t, Write(cfg, map[Variable[[]string]][]string{tagsKey: {"a", "b"}}))
+		return cfg
+	}
+
+	t.Run("Dump", func(t *testing.T) {
+		assert.Equal(t, []Setting{
+			{Key: "DUMP_PORT", Type: "int", Value: "8080", Origin: "source file", Description: "Port of the HTTP server"},
+			{Key: "DUMP_SECRET", Type: "string", Value: Redacted, Sensitive: true, Origin: "source file"},
+			{Key: "DUMP_TAGS", Type: "[]string", Value: "a,b", Origin: "write"},
+			{Key: "DUMP_TIMEOUT", Type: "time.Duration", Value: "30s", Origin: "fallback"},
+		}, newConfig().Dump())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newConfig().DumpJSON(&buf))
+		assert.NotContains(t, buf.String(), "hunter2")
+
+		var settings []Setting
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &settings))
+		assert.Equal(t, newConfig().Dump(), settings)
+		assert.Contains(t, buf.String(), `"key": "DUMP_PORT"`)
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newConfig().DumpText(&buf))
+		assert.Equal(t, "KEY           TYPE           VALUE       ORIGIN       DESCRIPTION\n"+
+			"DUMP_PORT     int            8080        source file  Port of the HTTP server\n"+
+			"DUMP_SECRET   string         [REDACTED]  source file\n"+
+			"DUMP_TAGS     []string       a,b         write\n"+
+			"DUMP_TIMEOUT  time.Duration  30s         fallback\n", buf.String())
+	})
+}
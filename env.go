@@ -3,6 +3,7 @@ package configura
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Bool takes an environment key, and a fallback value. Returns environment variable with converted type, or fallback
@@ -185,3 +186,107 @@ func Float64(key Variable[float64], fallback float64) float64 {
 	}
 	return fallback
 }
+
+// Duration takes an environment key, and a fallback value. Returns environment variable parsed with
+// time.ParseDuration (e.g. "1m30s"), or fallback value if it fails.
+func Duration(key Variable[time.Duration], fallback time.Duration) time.Duration {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vDuration, err := time.ParseDuration(vStr); err == nil {
+			return vDuration
+		}
+	}
+	return fallback
+}
+
+// Strings takes an environment key, a separator, and a fallback value. Returns environment variable split on sep with
+// whitespace trimmed from each element, or fallback value if it fails or sep is empty. Elements may be wrapped in
+// single or double quotes, and a backslash escapes the character that follows it.
+func Strings(key Variable[[]string], sep string, fallback []string) []string {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vStrings, err := splitList(vStr, sep); err == nil {
+			return vStrings
+		}
+	}
+	return fallback
+}
+
+// Ints takes an environment key, a separator, and a fallback value. Returns environment variable split on sep and
+// converted element by element, or fallback value if any element fails or sep is empty.
+func Ints(key Variable[[]int], sep string, fallback []int) []int {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vInts, err := parseList(vStr, sep, strconv.Atoi); err == nil {
+			return vInts
+		}
+	}
+	return fallback
+}
+
+// Float64s takes an environment key, a separator, and a fallback value. Returns environment variable split on sep and
+// converted element by element, or fallback value if any element fails or sep is empty.
+func Float64s(key Variable[[]float64], sep string, fallback []float64) []float64 {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vFloats, err := parseList(vStr, sep, parseFloat64); err == nil {
+			return vFloats
+		}
+	}
+	return fallback
+}
+
+// Bools takes an environment key, a separator, and a fallback value. Returns environment variable split on sep and
+// converted element by element, or fallback value if any element fails or sep is empty.
+func Bools(key Variable[[]bool], sep string, fallback []bool) []bool {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vBools, err := parseList(vStr, sep, strconv.ParseBool); err == nil {
+			return vBools
+		}
+	}
+	return fallback
+}
+
+// StringMap takes an environment key, an entry separator, a pair separator, and a fallback value. Returns environment
+// variable parsed as key/value pairs such as "a=1,b=2", or fallback value if it fails. The separators must be
+// non-empty and differ from each other, or the fallback value is returned.
+func StringMap(key Variable[map[string]string], entrySep, pairSep string, fallback map[string]string) map[string]string {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vMap, err := parseMap(vStr, entrySep, pairSep, parseString); err == nil {
+			return vMap
+		}
+	}
+	return fallback
+}
+
+// IntMap takes an environment key, an entry separator, a pair separator, and a fallback value. Returns environment
+// variable parsed as key/value pairs with converted values, or fallback value if any entry fails or the separators
+// are empty or equal.
+func IntMap(key Variable[map[string]int], entrySep, pairSep string, fallback map[string]int) map[string]int {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vMap, err := parseMap(vStr, entrySep, pairSep, strconv.Atoi); err == nil {
+			return vMap
+		}
+	}
+	return fallback
+}
+
+// Int64Map takes an environment key, an entry separator, a pair separator, and a fallback value. Returns environment
+// variable parsed as key/value pairs with converted values, or fallback value if any entry fails or the separators
+// are empty or equal.
+func Int64Map(key Variable[map[string]int64], entrySep, pairSep string, fallback map[string]int64) map[string]int64 {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vMap, err := parseMap(vStr, entrySep, pairSep, parseInt64); err == nil {
+			return vMap
+		}
+	}
+	return fallback
+}
+
+// Float64Map takes an environment key, an entry separator, a pair separator, and a fallback value. Returns environment
+// variable parsed as key/value pairs with converted values, or fallback value if any entry fails or the separators
+// are empty or equal.
+func Float64Map(key Variable[map[string]float64], entrySep, pairSep string, fallback map[string]float64) map[string]float64 {
+	if vStr, ok := os.LookupEnv(string(key)); ok {
+		if vMap, err := parseMap(vStr, entrySep, pairSep, parseFloat64); err == nil {
+			return vMap
+		}
+	}
+	return fallback
+}
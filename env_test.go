@@ -3,6 +3,7 @@ package configura_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Kansuler/configura"
 	"github.com/stretchr/testify/assert"
@@ -117,6 +118,34 @@ func init() {
 	_ = os.Setenv("valid_float64_4", "9223372036854775808")
 	_ = os.Setenv("invalid_float64_1", "")
 	_ = os.Setenv("invalid_float64_2", "test")
+
+	// Duration
+	_ = os.Setenv("valid_duration_1", "1m30s")
+	_ = os.Setenv("valid_duration_2", "250ms")
+	_ = os.Setenv("invalid_duration_1", "")
+	_ = os.Setenv("invalid_duration_2", "30")
+
+	// Slices
+	_ = os.Setenv("valid_strings_1", "a, b ,c")
+	_ = os.Setenv("valid_strings_2", `"x;y";z`)
+	_ = os.Setenv("valid_strings_3", "")
+	_ = os.Setenv("invalid_strings_1", `"unterminated`)
+	_ = os.Setenv("valid_ints_1", "1,2, 3")
+	_ = os.Setenv("invalid_ints_1", "1,two,3")
+	_ = os.Setenv("valid_float64s_1", "1.5;-2")
+	_ = os.Setenv("invalid_float64s_1", "1.5,abc")
+	_ = os.Setenv("valid_bools_1", "true, false,1")
+	_ = os.Setenv("invalid_bools_1", "true,maybe")
+
+	// Maps
+	_ = os.Setenv("valid_string_map_1", "X-Env=prod, X-Team = core")
+	_ = os.Setenv("invalid_string_map_1", "X-Env")
+	_ = os.Setenv("valid_int_map_1", "tenant_a:10;tenant_b:20")
+	_ = os.Setenv("invalid_int_map_1", "tenant_a=ten")
+	_ = os.Setenv("valid_int64_map_1", "big=9223372036854775807")
+	_ = os.Setenv("invalid_int64_map_1", "big=9223372036854775808")
+	_ = os.Setenv("valid_float64_map_1", "a=0.5,b=2")
+	_ = os.Setenv("invalid_float64_map_1", "a=half")
 }
 
 func TestBool(t *testing.T) {
@@ -319,3 +348,86 @@ func TestFloat64(t *testing.T) {
 	invalid2 := configura.Float64("invalid_float64_2", 22.33)
 	assert.Equal(t, 22.33, invalid2)
 }
+
+func TestDuration(t *testing.T) {
+	valid1 := configura.Duration("valid_duration_1", 0)
+	assert.Equal(t, 90*time.Second, valid1)
+	valid2 := configura.Duration("valid_duration_2", 0)
+	assert.Equal(t, 250*time.Millisecond, valid2)
+	invalid1 := configura.Duration("invalid_duration_1", time.Second)
+	assert.Equal(t, time.Second, invalid1)
+	invalid2 := configura.Duration("invalid_duration_2", time.Minute)
+	assert.Equal(t, time.Minute, invalid2)
+}
+
+func TestStrings(t *testing.T) {
+	valid1 := configura.Strings("valid_strings_1", ",", nil)
+	assert.Equal(t, []string{"a", "b", "c"}, valid1)
+	valid2 := configura.Strings("valid_strings_2", ";", nil)
+	assert.Equal(t, []string{"x;y", "z"}, valid2)
+	valid3 := configura.Strings("valid_strings_3", ",", []string{"foo"})
+	assert.Equal(t, []string{}, valid3)
+	invalid1 := configura.Strings("invalid_strings_1", ",", []string{"foo"})
+	assert.Equal(t, []string{"foo"}, invalid1)
+	unset := configura.Strings("unset_strings_1", ",", []string{"foo"})
+	assert.Equal(t, []string{"foo"}, unset)
+	emptySep := configura.Strings("valid_strings_1", "", []string{"foo"})
+	assert.Equal(t, []string{"foo"}, emptySep)
+}
+
+func TestInts(t *testing.T) {
+	valid1 := configura.Ints("valid_ints_1", ",", nil)
+	assert.Equal(t, []int{1, 2, 3}, valid1)
+	invalid1 := configura.Ints("invalid_ints_1", ",", []int{9})
+	assert.Equal(t, []int{9}, invalid1)
+	emptySep := configura.Ints("valid_ints_1", "", []int{9})
+	assert.Equal(t, []int{9}, emptySep)
+}
+
+func TestFloat64s(t *testing.T) {
+	valid1 := configura.Float64s("valid_float64s_1", ";", nil)
+	assert.Equal(t, []float64{1.5, -2}, valid1)
+	invalid1 := configura.Float64s("invalid_float64s_1", ",", []float64{9.9})
+	assert.Equal(t, []float64{9.9}, invalid1)
+}
+
+func TestBools(t *testing.T) {
+	valid1 := configura.Bools("valid_bools_1", ",", nil)
+	assert.Equal(t, []bool{true, false, true}, valid1)
+	invalid1 := configura.Bools("invalid_bools_1", ",", []bool{false})
+	assert.Equal(t, []bool{false}, invalid1)
+}
+
+func TestStringMap(t *testing.T) {
+	valid1 := configura.StringMap("valid_string_map_1", ",", "=", nil)
+	assert.Equal(t, map[string]string{"X-Env": "prod", "X-Team": "core"}, valid1)
+	invalid1 := configura.StringMap("invalid_string_map_1", ",", "=", map[string]string{"foo": "bar"})
+	assert.Equal(t, map[string]string{"foo": "bar"}, invalid1)
+	emptyEntrySep := configura.StringMap("valid_string_map_1", "", "=", map[string]string{"foo": "bar"})
+	assert.Equal(t, map[string]string{"foo": "bar"}, emptyEntrySep)
+	emptyPairSep := configura.StringMap("valid_string_map_1", ",", "", map[string]string{"foo": "bar"})
+	assert.Equal(t, map[string]string{"foo": "bar"}, emptyPairSep)
+	equalSeps := configura.StringMap("valid_string_map_1", ",", ",", map[string]string{"foo": "bar"})
+	assert.Equal(t, map[string]string{"foo": "bar"}, equalSeps)
+}
+
+func TestIntMap(t *testing.T) {
+	valid1 := configura.IntMap("valid_int_map_1", ";", ":", nil)
+	assert.Equal(t, map[string]int{"tenant_a": 10, "tenant_b": 20}, valid1)
+	invalid1 := configura.IntMap("invalid_int_map_1", ",", "=", map[string]int{"foo": 1})
+	assert.Equal(t, map[string]int{"foo": 1}, invalid1)
+}
+
+func TestInt64Map(t *testing.T) {
+	valid1 := configura.Int64Map("valid_int64_map_1", ",", "=", nil)
+	assert.Equal(t, map[string]int64{"big": 9223372036854775807}, valid1)
+	invalid1 := configura.Int64Map("invalid_int64_map_1", ",", "=", map[string]int64{"foo": 1})
+	assert.Equal(t, map[string]int64{"foo": 1}, invalid1)
+}
+
+func TestFloat64Map(t *testing.T) {
+	valid1 := configura.Float64Map("valid_float64_map_1", ",", "=", nil)
+	assert.Equal(t, map[string]float64{"a": 0.5, "b": 2}, valid1)
+	invalid1 := configura.Float64Map("invalid_float64_map_1", ",", "=", map[string]float64{"foo": 1})
+	assert.Equal(t, map[string]float64{"foo": 1}, invalid1)
+}
@@ -0,0 +1,149 @@
+package configura
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// FlagLayer is the name of the layer that FlagSet adds to a configuration.
+const FlagLayer = "flags"
+
+// flag returns the usage and the flag.Value of the flag of the variable, which defaults to the raw form of its fallback
+// unless the variable is required or sensitive.
+func (d *declared[T]) flag(cfg *Config) (string, flag.Value) {
+	usage := fmt.Sprintf("`%s` value of %s", typeName(d.key), d.key)
+	meta, _ := MetadataOf(d.key)
+	if meta.Description != "" {
+		usage = fmt.Sprintf("`%s` %s", typeName(d.key), meta.Description)
+	}
+	if meta.Deprecated != "" {
+		usage += " (deprecated: " + meta.Deprecated + ")"
+	}
+	value := &flagValue[T]{cfg: cfg, key: d.key}
+	if d.required {
+		usage += " (required)"
+	} else if !cfg.isSensitive(d.key) {
+		value.raw = format(cfg, d.fallback)
+	}
+	return usage, value
+}
+
+// flagValue is the flag.Value of a Variable[T]. Setting it checks that the raw value parses in the same way as Load
+// does, and resolves the variable again so that the flag layer takes effect.
+type flagValue[T any] struct {
+	cfg *Config
+	key Variable[T]
+	raw string
+	set bool
+}
+
+// String returns the raw value that the flag was set to, or the raw form of the fallback of its variable.
+func (f *flagValue[T]) String() string {
+	return f.raw
+}
+
+// Set checks that raw can be parsed, and resolves the variable of the flag again.
+func (f *flagValue[T]) Set(raw string) error {
+	defer f.cfg.unlock(f.cfg.lock())
+	if _, err := parse(f.cfg, f.key, raw); err != nil {
+		return err
+	}
+	f.raw, f.set = raw, true
+
+	// Every variable of the same name takes the value from the flag layer, whatever its type, as it would from any
+	// other layer.
+	for key, decl := range f.cfg.sortedDeclarations() {
+		if _, ok := f.cfg.written[key]; !ok && decl.name() == string(f.key) {
+			decl.resolve(f.cfg)
+		}
+	}
+	return nil
+}
+
+// IsBoolFlag allows boolean flags to be given without a value, such as -debug.
+func (f *flagValue[T]) IsBoolFlag() bool {
+	_, ok := any(f.key).(Variable[bool])
+	return ok
+}
+
+// lookup returns the raw value of the flag if it was set, and belongs to the variable named key.
+func (f *flagValue[T]) lookup(key string) (string, bool) {
+	if !f.set || string(f.key) != key {
+		return "", false
+	}
+	return f.raw, true
+}
+
+// flagLayer is the Source of the layer that FlagSet adds to a configuration, holding the flags that were set.
+type flagLayer struct {
+	fs *flag.FlagSet
+}
+
+// Lookup returns the raw value of the flag for the variable named key, if it was set.
+func (l flagLayer) Lookup(key string) (string, bool) {
+	if f := l.fs.Lookup(FlagName(key)); f != nil {
+		if v, ok := f.Value.(interface{ lookup(string) (string, bool) }); ok {
+			return v.lookup(key)
+		}
+	}
+	return "", false
+}
+
+// Name returns FlagLayer.
+func (flagLayer) Name() string {
+	return FlagLayer
+}
+
+// FlagName maps the name of a variable to the name of its command-line flag, such that DATABASE_URL becomes
+// database-url.
+func FlagName(key string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(key))
+}
+
+// FlagSet returns a flag.FlagSet with a flag for every variable declared through Load, LoadStrict or LoadRequired,
+// named with FlagName. Each flag accepts the same syntax as Load does for the type of its variable, and defaults to the
+// fallback that the variable was loaded with, which is not shown for sensitive variables. The usage of a flag is the
+// description of its variable, if it was described with Describe. Boolean flags may be given without a value, such as
+// -debug. If two variables map to the same flag name, only the first in alphabetical order of name and type gets a
+// flag. Like any other layer, the flag layer holds values by name, so a variable that shares the name of the variable
+// of a flag but has a different type takes the value of the flag as well, and records a ParseError if it cannot be
+// parsed as its own type.
+//
+// The flags that are set while parsing the command line form a layer named FlagLayer, which is added on top of the
+// other layers of the configuration, or replaces the flag layer of an earlier FlagSet. Variables that were loaded from
+// sources given to Load itself are not affected by flags.
+func (c *Config) FlagSet(name string, errorHandling flag.ErrorHandling) *flag.FlagSet {
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+
+	fs := flag.NewFlagSet(name, errorHandling)
+	for _, decl := range c.sortedDeclarations() {
+		flagName := FlagName(decl.name())
+		if fs.Lookup(flagName) != nil {
+			continue
+		}
+		usage, value := decl.flag(c)
+		fs.Var(value, flagName, usage)
+	}
+
+	c.addLayer(Layer{Name: FlagLayer, Source: flagLayer{fs: fs}})
+	return fs
+}
+
+// FlagSource returns a Source holding the flags of fs that were set on the command line, looked up by the FlagName of
+// a variable. It lets variables take their value from the flags of any flag.FlagSet, with the precedence given by the
+// order of the layers.
+func FlagSource(fs *flag.FlagSet) Source {
+	return SourceFunc(func(key string) (string, bool) {
+		var raw string
+		var found bool
+		name := FlagName(key)
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == name {
+				raw, found = f.Value.String(), true
+			}
+		})
+		return raw, found
+	})
+}
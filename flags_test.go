@@ -0,0 +1,146 @@
+package configura
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagName(t *testing.T) {
+	assert.Equal(t, "database-url", FlagName("DATABASE_URL"))
+	assert.Equal(t, "port", FlagName("PORT"))
+	assert.Equal(t, "database-url", FlagName("database.url"))
+}
+
+func TestFlagSet(t *testing.T) {
+	portKey := Variable[int]("FLAG_PORT")
+	urlKey := Variable[string]("FLAG_DATABASE_URL")
+	debugKey := Variable[bool]("FLAG_DEBUG")
+	timeoutKey := Variable[time.Duration]("FLAG_TIMEOUT")
+	originsKey := Variable[[]string]("FLAG_ORIGINS")
+	apiKey := Variable[string]("FLAG_API_KEY")
+	writtenKey := Variable[string]("FLAG_WRITTEN")
+
+	newConfig := func(t *testing.T) *Config {
+		t.Helper()
+		cfg := New(MapSource{string(portKey): "8080", string(apiKey): "secret"})
+		Load(cfg, portKey, 3000)
+		Load(cfg, urlKey, "postgres://localhost/db")
+		Load(cfg, debugKey, false)
+		Load(cfg, timeoutKey, 30*time.Second)
+		Load(cfg, originsKey, []string{"a", " b"})
+		require.NoError(t, LoadRequired(cfg, apiKey))
+		Write(cfg, map[Variable[string]]string{writtenKey: "written"})
+		return cfg
+	}
+
+	t.Run("Flags", func(t *testing.T) {
+		fs := newConfig(t).FlagSet("test", flag.ContinueOnError)
+
+		assert.Equal(t, "3000", fs.Lookup("flag-port").DefValue, "The default should be the fallback")
+		assert.Equal(t, "postgres://localhost/db", fs.Lookup("flag-database-url").DefValue)
+		assert.Equal(t, "false", fs.Lookup("flag-debug").DefValue)
+		assert.Equal(t, "30s", fs.Lookup("flag-timeout").DefValue)
+		assert.Equal(t, `a," b"`, fs.Lookup("flag-origins").DefValue)
+		assert.Equal(t, "", fs.Lookup("flag-api-key").DefValue)
+		assert.Equal(t, "`int` value of FLAG_PORT", fs.Lookup("flag-port").Usage)
+		assert.Equal(t, "`string` value of FLAG_API_KEY (required)", fs.Lookup("flag-api-key").Usage)
+		assert.Nil(t, fs.Lookup("flag-written"), "Only variables declared through Load should get a flag")
+	})
+
+	t.Run("ParseOverridesSources", func(t *testing.T) {
+		cfg := newConfig(t)
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		require.NoError(t, fs.Parse([]string{"--flag-port=9090", "-flag-debug", "--flag-timeout", "1m", "--flag-origins=x,y", "--flag-api-key=other"}))
+
+		assert.Equal(t, 9090, cfg.Int(portKey))
+		assert.True(t, cfg.Bool(debugKey))
+		assert.Equal(t, time.Minute, cfg.Duration(timeoutKey))
+		assert.Equal(t, []string{"x", "y"}, cfg.Strings(originsKey))
+		assert.Equal(t, "other", cfg.String(apiKey))
+		assert.Equal(t, "postgres://localhost/db", cfg.String(urlKey), "Flags that are not set should leave the variable alone")
+	})
+
+	t.Run("FlagLayer", func(t *testing.T) {
+		cfg := newConfig(t)
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		layers := cfg.Layers()
+		require.NotEmpty(t, layers)
+		assert.Equal(t, FlagLayer, layers[len(layers)-1].Name, "The flags should take precedence over the other layers")
+
+		Write(cfg, map[Variable[int]]int{portKey: 1})
+		require.NoError(t, fs.Parse([]string{"--flag-port=9090"}))
+		assert.Equal(t, 1, cfg.Int(portKey), "Written values should take precedence over flags")
+		cfg.Reset(portKey)
+		assert.Equal(t, 9090, cfg.Int(portKey))
+
+		assert.True(t, cfg.RemoveLayer(FlagLayer))
+		assert.Equal(t, 8080, cfg.Int(portKey))
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		cfg := newConfig(t)
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(&bytes.Buffer{})
+		err := fs.Parse([]string{"--flag-port=80a0"})
+		assert.ErrorContains(t, err, `invalid value "80a0" for flag -flag-port`)
+		assert.Equal(t, 8080, cfg.Int(portKey))
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		fs := newConfig(t).FlagSet("test", flag.ContinueOnError)
+		var out bytes.Buffer
+		fs.SetOutput(&out)
+		fs.PrintDefaults()
+		assert.Contains(t, out.String(), "-flag-port int\n    \tint value of FLAG_PORT (default 3000)")
+		assert.Contains(t, out.String(), "-flag-api-key string\n    \tstring value of FLAG_API_KEY (required)\n")
+	})
+
+	t.Run("NameCollision", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, Variable[string]("FLAG_COLLISION"), "string")
+		Load(cfg, Variable[int]("flag.collision"), 1)
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		assert.Equal(t, "string", fs.Lookup("flag-collision").DefValue)
+	})
+
+	t.Run("SharedName", func(t *testing.T) {
+		stringKey := Variable[string]("FLAG_SHARED")
+		intKey := Variable[int]("FLAG_SHARED")
+		cfg := New(MapSource{})
+		Load(cfg, intKey, 1)
+		Load(cfg, stringKey, "a")
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		assert.Equal(t, "1", fs.Lookup("flag-shared").DefValue, "Only the first variable should get a flag")
+
+		require.NoError(t, fs.Parse([]string{"-flag-shared", "2"}))
+		assert.Equal(t, 2, cfg.Int(intKey))
+		assert.Equal(t, "2", cfg.String(stringKey), "Every variable of the name should take the value of the flag")
+		require.NoError(t, cfg.Refresh())
+		assert.Equal(t, "2", cfg.String(stringKey))
+	})
+}
+
+func TestFlagSource(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("flag-host", "default", "")
+	fs.Int("flag-port", 0, "")
+	require.NoError(t, fs.Parse([]string{"--flag-host=example.com"}))
+
+	src := FlagSource(fs)
+	raw, ok := src.Lookup("FLAG_HOST")
+	assert.True(t, ok)
+	assert.Equal(t, "example.com", raw)
+	_, ok = src.Lookup("FLAG_PORT")
+	assert.False(t, ok, "Flags that were not set should not be found")
+
+	cfg := New(MapSource{"FLAG_HOST": "file", "FLAG_PORT": "8080"}, src)
+	Load(cfg, Variable[string]("FLAG_HOST"), "")
+	Load(cfg, Variable[int]("FLAG_PORT"), 0)
+	assert.Equal(t, "example.com", cfg.String("FLAG_HOST"))
+	assert.Equal(t, 8080, cfg.Int("FLAG_PORT"))
+}
@@ -0,0 +1,61 @@
+package configura
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// JSONFile reads the JSON document at path with ParseJSON. The locations of its values are prefixed with path.
+func JSONFile(path string, mapper KeyMapper) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	root, err := decodeJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return newDocument(root, mapper, jsonPath, path)
+}
+
+// ParseJSON parses a JSON document whose top level is an object into a Document. Nested objects are flattened into
+// variable names with mapper, which defaults to UpperSnakeCase when nil. Numbers keep their exact decimal form, and
+// null values are treated as if they were absent. The location of each value is its path, such as database.url.
+func ParseJSON(r io.Reader, mapper KeyMapper) (*Document, error) {
+	root, err := decodeJSON(r)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(root, mapper, jsonPath, "")
+}
+
+// decodeJSON decodes a single JSON object from r.
+func decodeJSON(r io.Reader) (map[string]any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the top-level object")
+	}
+
+	object, ok := root.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an object at the top level, found %s", kindOf(root))
+	}
+	return object, nil
+}
+
+// jsonPath formats the path of a JSON value.
+func jsonPath(path []string) string {
+	return strings.Join(path, ".")
+}
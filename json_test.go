@@ -0,0 +1,156 @@
+package configura
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testJSON = `{
+	"port": 8080,
+	"debug": true,
+	"database": {
+		"url": "postgres://localhost/db",
+		"max-connections": 10,
+		"timeout": "5s"
+	},
+	"allowed_origins": ["https://a.example.com", "https://b.example.com"],
+	"rate_limits": {"acme": 100, "globex": 250},
+	"ratio": 0.25,
+	"unset": null
+}`
+
+func TestParseJSON(t *testing.T) {
+	t.Run("UpperSnakeCase", func(t *testing.T) {
+		doc, err := ParseJSON(strings.NewReader(testJSON), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		Load(cfg, Variable[int]("PORT"), 3000)
+		Load(cfg, Variable[bool]("DEBUG"), false)
+		Load(cfg, Variable[string]("DATABASE_URL"), "")
+		Load(cfg, Variable[uint8]("DATABASE_MAX_CONNECTIONS"), 1)
+		Load(cfg, Variable[time.Duration]("DATABASE_TIMEOUT"), time.Second)
+		Load(cfg, Variable[[]string]("ALLOWED_ORIGINS"), nil)
+		Load(cfg, Variable[map[string]int]("RATE_LIMITS"), nil)
+		Load(cfg, Variable[map[string]string]("DATABASE"), nil)
+		Load(cfg, Variable[float64]("RATIO"), 1)
+		Load(cfg, Variable[string]("UNSET"), "fallback")
+
+		assert.Equal(t, 8080, cfg.Int("PORT"))
+		assert.True(t, cfg.Bool("DEBUG"))
+		assert.Equal(t, "postgres://localhost/db", cfg.String("DATABASE_URL"))
+		assert.Equal(t, uint8(10), cfg.Uint8("DATABASE_MAX_CONNECTIONS"))
+		assert.Equal(t, 5*time.Second, cfg.Duration("DATABASE_TIMEOUT"))
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Strings("ALLOWED_ORIGINS"))
+		assert.Equal(t, map[string]int{"acme": 100, "globex": 250}, cfg.IntMap("RATE_LIMITS"))
+		assert.Equal(t, map[string]string{"url": "postgres://localhost/db", "max-connections": "10", "timeout": "5s"}, cfg.StringMap("DATABASE"))
+		assert.Equal(t, 0.25, cfg.Float64("RATIO"))
+		assert.Equal(t, "fallback", cfg.String("UNSET"), "A null value should be treated as absent")
+	})
+
+	t.Run("DotPath", func(t *testing.T) {
+		doc, err := ParseJSON(strings.NewReader(testJSON), DotPath)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		Load(cfg, Variable[string]("database.url"), "")
+		Load(cfg, Variable[int]("database.max-connections"), 1)
+		assert.Equal(t, "postgres://localhost/db", cfg.String("database.url"))
+		assert.Equal(t, 10, cfg.Int("database.max-connections"))
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		doc, err := ParseJSON(strings.NewReader(testJSON), nil)
+		require.NoError(t, err)
+
+		raw, ok := doc.Lookup("PORT")
+		assert.True(t, ok)
+		assert.Equal(t, "8080", raw)
+		raw, _ = doc.Lookup("DATABASE_URL")
+		assert.Equal(t, "postgres://localhost/db", raw)
+		raw, _ = doc.Lookup("ALLOWED_ORIGINS")
+		assert.Equal(t, `["https://a.example.com","https://b.example.com"]`, raw)
+		_, ok = doc.Lookup("MISSING")
+		assert.False(t, ok)
+	})
+
+	t.Run("TypeMismatchReportsPath", func(t *testing.T) {
+		doc, err := ParseJSON(strings.NewReader(`{"server": {"port": "80a0", "debug": 1}}`), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		err = LoadStrict(cfg, Variable[bool]("SERVER_DEBUG"), false)
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "server.debug", parseErr.Location)
+		assert.Equal(t, "1", parseErr.Value)
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+		assert.Equal(t, `SERVER_DEBUG (server.debug): cannot parse "1" as bool: mismatched type: expected a boolean, found a number`, err.Error())
+
+		err = LoadStrict(cfg, Variable[int]("SERVER_PORT"), 3000)
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "server.port", parseErr.Location)
+		assert.Equal(t, 3000, cfg.Int("SERVER_PORT"))
+	})
+
+	t.Run("EnvironmentOverrides", func(t *testing.T) {
+		t.Setenv("PORT", "9090")
+		doc, err := ParseJSON(strings.NewReader(testJSON), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc, Env)
+		Load(cfg, Variable[int]("PORT"), 3000)
+		Load(cfg, Variable[bool]("DEBUG"), false)
+		assert.Equal(t, 9090, cfg.Int("PORT"))
+		assert.True(t, cfg.Bool("DEBUG"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseJSON(strings.NewReader(`{"a": `), nil)
+		assert.Error(t, err)
+		_, err = ParseJSON(strings.NewReader(`[1, 2]`), nil)
+		assert.EqualError(t, err, "expected an object at the top level, found an array")
+		_, err = ParseJSON(strings.NewReader(`{} {}`), nil)
+		assert.EqualError(t, err, "unexpected data after the top-level object")
+	})
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		for range 20 {
+			_, err := ParseJSON(strings.NewReader(`{"database": {"url": "a"}, "DATABASE_URL": "b", "database-url": "c"}`), nil)
+			assert.EqualError(t, err, "DATABASE_URL and database.url both map to DATABASE_URL")
+		}
+		_, err := ParseJSON(strings.NewReader(`{"database_url": null, "database": {"url": "a"}}`), nil)
+		assert.NoError(t, err, "Null values should not collide")
+	})
+}
+
+func TestJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"server": {"port": true}}`), 0o600))
+
+	doc, err := JSONFile(path, nil)
+	require.NoError(t, err)
+	v, ok := doc.LookupValue("SERVER_PORT")
+	require.True(t, ok)
+	assert.Equal(t, path+": server.port", v.Location)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"server": {"port": 1}, "SERVER_PORT": 2}`), 0o600))
+	_, err = JSONFile(path, nil)
+	assert.EqualError(t, err, path+": SERVER_PORT and server.port both map to SERVER_PORT")
+
+	_, err = JSONFile(filepath.Join(t.TempDir(), "missing.json"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestKeyMappers(t *testing.T) {
+	assert.Equal(t, "DATABASE_URL", UpperSnakeCase([]string{"database", "url"}))
+	assert.Equal(t, "HTTP_READ_TIMEOUT", UpperSnakeCase([]string{"http", "read-timeout"}))
+	assert.Equal(t, "A_B_C", UpperSnakeCase([]string{"a.b", "c"}))
+	assert.Equal(t, "database.url", DotPath([]string{"database", "url"}))
+}
@@ -0,0 +1,128 @@
+package configura
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Layer is a named Source within the precedence order of a Config. Layers are ordered from the lowest to the highest
+// precedence, such as defaults, a file, the environment and command-line flags. Values written with Write are always
+// above every layer.
+type Layer struct {
+	Name   string
+	Source Source
+}
+
+// SourceName returns the name of the layer that New creates for src, which is EnvLayer for Env, the result of a Name
+// method if src has one, or else the type of src.
+func SourceName(src Source) string {
+	if named, ok := src.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T", src)
+}
+
+// Layers returns the layers of the configuration, from the lowest to the highest precedence.
+func (c *Config) Layers() []Layer {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+	return slices.Clone(c.layers)
+}
+
+// SetLayers replaces the layers of the configuration, ordered from the lowest to the highest precedence, and resolves
+// every loaded variable again.
+func (c *Config) SetLayers(layers ...Layer) {
+	defer c.unlock(c.lock())
+	c.layers = slices.Clone(layers)
+	c.refresh()
+}
+
+// AddLayer adds src as the layer with the highest precedence, or replaces the source of the layer that is already
+// named name, and resolves every loaded variable again.
+func (c *Config) AddLayer(name string, src Source) {
+	defer c.unlock(c.lock())
+	c.addLayer(Layer{Name: name, Source: src})
+	c.refresh()
+}
+
+// addLayer adds layer on top of the others, or in place of the layer with the same name. The caller must hold the
+// write lock.
+func (c *Config) addLayer(layer Layer) {
+	if i := slices.IndexFunc(c.layers, func(l Layer) bool { return l.Name == layer.Name }); i >= 0 {
+		c.layers[i] = layer
+		return
+	}
+	c.layers = append(c.layers, layer)
+}
+
+// RemoveLayer removes every layer named name, and resolves every loaded variable again, so that the values of the
+// layers underneath are exposed. It reports whether any layer was removed.
+func (c *Config) RemoveLayer(name string) bool {
+	defer c.unlock(c.lock())
+	n := len(c.layers)
+	c.layers = slices.DeleteFunc(c.layers, func(l Layer) bool { return l.Name == name })
+	if len(c.layers) == n {
+		return false
+	}
+	c.refresh()
+	return true
+}
+
+// Refresh resolves every variable loaded through Load, LoadStrict or LoadRequired again, so that changes to the
+// content of the layers take effect. Values written with Write are left untouched. It returns the same error as Err.
+func (c *Config) Refresh() error {
+	before := c.lock()
+	c.refresh()
+	c.unlock(before)
+	return c.Err()
+}
+
+// Reset discards the values written with Write for keys, and exposes the values of the layers underneath instead. A
+// key that was not loaded through one of the Load functions is removed from the configuration.
+func (c *Config) Reset(keys ...any) {
+	defer c.unlock(c.lock())
+	for _, key := range keys {
+		delete(c.written, key)
+		if decl, ok := c.declarations[key]; ok {
+			decl.resolve(c)
+		} else {
+			c.remove(key)
+		}
+	}
+}
+
+// refresh resolves every declared variable that was not written, in order of name, and returns the values that failed
+// to parse, whether or not they were loaded strictly. The caller must hold the write lock.
+func (c *Config) refresh() []ParseError {
+	var parseErrs []ParseError
+	for key, decl := range c.sortedDeclarations() {
+		if _, ok := c.written[key]; !ok {
+			if err := decl.resolve(c); err != nil {
+				parseErrs = append(parseErrs, *err)
+			}
+		}
+	}
+	return parseErrs
+}
+
+// sortedDeclarations iterates over the declared variables in order of name and type. The caller must hold the lock.
+func (c *Config) sortedDeclarations() iter.Seq2[any, declaration] {
+	keys := make([]any, 0, len(c.declarations))
+	for key := range c.declarations {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b any) int {
+		da, db := c.declarations[a], c.declarations[b]
+		return cmp.Or(cmp.Compare(da.name(), db.name()), cmp.Compare(da.typeName(), db.typeName()))
+	})
+
+	return func(yield func(any, declaration) bool) {
+		for _, key := range keys {
+			if !yield(key, c.declarations[key]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package configura
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceName(t *testing.T) {
+	assert.Equal(t, EnvLayer, SourceName(Env))
+	assert.Equal(t, "configura.MapSource", SourceName(MapSource{}))
+	assert.Equal(t, FlagLayer, SourceName(flagLayer{}))
+}
+
+func TestLayers(t *testing.T) {
+	portKey := Variable[int]("LAYER_PORT")
+	hostKey := Variable[string]("LAYER_HOST")
+	require.NoError(t, os.Unsetenv(string(portKey)))
+	require.NoError(t, os.Unsetenv(string(hostKey)))
+
+	t.Run("Precedence", func(t *testing.T) {
+		t.Setenv(string(portKey), "3000")
+		cfg := New(MapSource{string(portKey): "2000", string(hostKey): "file"}, Env)
+		cfg.AddLayer("flags", MapSource{string(portKey): "4000"})
+		Load(cfg, portKey, 1000)
+		Load(cfg, hostKey, "localhost")
+		assert.Equal(t, 4000, cfg.Int(portKey))
+		assert.Equal(t, "file", cfg.String(hostKey))
+
+		names := make([]string, 0, 3)
+		for _, layer := range cfg.Layers() {
+			names = append(names, layer.Name)
+		}
+		assert.Equal(t, []string{"configura.MapSource", EnvLayer, "flags"}, names)
+
+		Write(cfg, map[Variable[int]]int{portKey: 5000})
+		assert.Equal(t, 5000, cfg.Int(portKey), "Written values should take precedence over every layer")
+
+		assert.True(t, cfg.RemoveLayer("flags"))
+		assert.Equal(t, 5000, cfg.Int(portKey))
+		cfg.Reset(portKey)
+		assert.Equal(t, 3000, cfg.Int(portKey), "Reset should expose the layers underneath")
+
+		assert.True(t, cfg.RemoveLayer(EnvLayer))
+		assert.Equal(t, 2000, cfg.Int(portKey))
+		assert.True(t, cfg.RemoveLayer("configura.MapSource"))
+		assert.Equal(t, 1000, cfg.Int(portKey), "Without layers the fallback should be used")
+		assert.Equal(t, "localhost", cfg.String(hostKey))
+		assert.False(t, cfg.RemoveLayer("missing"))
+	})
+
+	t.Run("AddLayerReplacesByName", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "2000"})
+		cfg.AddLayer("override", MapSource{string(portKey): "3000"})
+		Load(cfg, portKey, 1000)
+		assert.Equal(t, 3000, cfg.Int(portKey))
+
+		cfg.AddLayer("override", MapSource{string(portKey): "4000"})
+		assert.Equal(t, 4000, cfg.Int(portKey))
+		assert.Len(t, cfg.Layers(), 2)
+	})
+
+	t.Run("SetLayers", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "2000"})
+		Load(cfg, portKey, 1000)
+		cfg.SetLayers(Layer{Name: "defaults", Source: MapSource{string(portKey): "3000"}})
+		assert.Equal(t, 3000, cfg.Int(portKey))
+		assert.Equal(t, []Layer{{Name: "defaults", Source: MapSource{string(portKey): "3000"}}}, cfg.Layers())
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		src := MapSource{string(portKey): "2000"}
+		cfg := New(src)
+		Load(cfg, portKey, 1000)
+		src[string(portKey)] = "3000"
+		assert.Equal(t, 2000, cfg.Int(portKey), "Changes to a source should only take effect after Refresh")
+		require.NoError(t, cfg.Refresh())
+		assert.Equal(t, 3000, cfg.Int(portKey))
+
+		cfg = New(src)
+		require.NoError(t, LoadStrict(cfg, portKey, 1000))
+		src[string(portKey)] = "30a0"
+		assert.ErrorIs(t, cfg.Refresh(), ErrInvalidVariable)
+		assert.Equal(t, 1000, cfg.Int(portKey))
+		src[string(portKey)] = "4000"
+		assert.NoError(t, cfg.Refresh(), "Errors of earlier refreshes should be cleared")
+		assert.Equal(t, 4000, cfg.Int(portKey))
+	})
+
+	t.Run("RequiredBecomesMissing", func(t *testing.T) {
+		cfg := New()
+		cfg.AddLayer("secrets", MapSource{string(hostKey): "db.internal"})
+		require.NoError(t, LoadRequired(cfg, hostKey))
+		assert.Equal(t, "db.internal", cfg.String(hostKey))
+
+		cfg.RemoveLayer("secrets")
+		assert.ErrorIs(t, cfg.Err(), ErrMissingVariable)
+		assert.Empty(t, cfg.String(hostKey))
+	})
+
+	t.Run("ResetUnloadedKey", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Write(cfg, map[Variable[string]]string{hostKey: "written"})
+		cfg.Reset(hostKey)
+		assert.ErrorIs(t, cfg.Exists(hostKey), ErrMissingVariable)
+	})
+
+	t.Run("MergedValuesSurviveSetLayers", func(t *testing.T) {
+		other := New(MapSource{})
+		Write(other, map[Variable[string]]string{hostKey: "merged"})
+		cfg := New(MapSource{string(hostKey): "file"})
+		Load(cfg, hostKey, "localhost")
+		cfg = Merge(cfg, other)
+		cfg.SetLayers()
+		assert.Equal(t, "merged", cfg.String(hostKey))
+	})
+}
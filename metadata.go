@@ -0,0 +1,146 @@
+package configura
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrDeprecatedVariable is matched by the DeprecatedVariableError that Config.Err reports when SetRejectDeprecated is
+// enabled.
+var ErrDeprecatedVariable = errors.New("deprecated configuration variables")
+
+// Metadata describes a variable for the people who set it. It is attached to a variable with Describe.
+type Metadata struct {
+	// Description tells what the variable configures, such as "Connection string of the primary database".
+	Description string
+	// Example is a value of the variable in its raw form, such as "postgres://localhost:5432/app".
+	Example string
+	// Owner is the team or person responsible for the variable.
+	Owner string
+	// Deprecated, if not empty, marks the variable as deprecated and tells what to use instead.
+	Deprecated string
+	// Sensitive marks the variable as sensitive in every configuration, like Config.MarkSensitive does for one.
+	Sensitive bool
+}
+
+var (
+	metadataLock sync.RWMutex
+	metadata     = map[any]Metadata{}
+)
+
+// Describe attaches meta to key, replacing any metadata it had, and returns key. The metadata is shared by every
+// configuration, and is used by the errors, Dump, DotenvExample and FlagSet. A deprecated variable that is still set is
+// listed by Config.Deprecated, so that a warning can be logged, and is reported by Config.Err as well once
+// Config.SetRejectDeprecated is enabled. Variables are best described from an init function of the package that
+// declares them, so that they are described before they are loaded:
+//
+//	const PORT configura.Variable[int] = "PORT"
+//
+//	func init() {
+//		configura.Describe(PORT, configura.Metadata{Description: "Port of the HTTP server", Example: "8080"})
+//	}
+func Describe[T any](key Variable[T], meta Metadata) Variable[T] {
+	metadataLock.Lock()
+	defer metadataLock.Unlock()
+	metadata[key] = meta
+	return key
+}
+
+// MetadataOf returns the metadata attached to key with Describe. It reports false if key was not described.
+func MetadataOf(key any) (Metadata, bool) {
+	metadataLock.RLock()
+	defer metadataLock.RUnlock()
+	meta, ok := metadata[key]
+	return meta, ok
+}
+
+// describedSensitive reports whether a variable named name, of type typ, was described as sensitive.
+func describedSensitive(name, typ string) bool {
+	metadataLock.RLock()
+	defer metadataLock.RUnlock()
+	for key, meta := range metadata {
+		if meta.Sensitive && keyName(key) == name && typeName(key) == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// description returns the description of key, or an empty string if it has none.
+func description(key any) string {
+	meta, _ := MetadataOf(key)
+	return meta.Description
+}
+
+// Deprecated returns the origin of every variable that is described as deprecated, yet took its value from a layer or
+// a source, ordered by name and type. It lists the deprecated variables that are still set, so that a warning can be
+// logged for each of them along with the Deprecated notice of its metadata.
+func (c *Config) Deprecated() []Provenance {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+	return c.deprecated()
+}
+
+// deprecated is the lock-free part of Deprecated. The caller must hold the lock.
+func (c *Config) deprecated() []Provenance {
+	var report []Provenance
+	for key, origin := range c.origins {
+		if meta, ok := MetadataOf(key); ok && meta.Deprecated != "" && origin.Kind == OriginSource {
+			report = append(report, Provenance{Key: keyName(key), Type: typeName(key), Origin: origin})
+		}
+	}
+	slices.SortFunc(report, func(a, b Provenance) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return report
+}
+
+// SetRejectDeprecated controls whether Config.Err reports the deprecated variables that are still set, as listed by
+// Deprecated, as a DeprecatedVariableError. It is disabled by default, so that a deprecated variable only warrants a
+// warning, and can be enabled once every deployment was expected to have moved on.
+func (c *Config) SetRejectDeprecated(reject bool) {
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	c.rejectDeprecated = reject
+}
+
+// DeprecatedVariableError lists the deprecated variables that are still set, along with the Deprecated notice of each
+// of them by name.
+type DeprecatedVariableError struct {
+	Keys    []string
+	Notices map[string]string
+}
+
+// newDeprecatedVariableError returns a DeprecatedVariableError for the variables of report.
+func newDeprecatedVariableError(report []Provenance) DeprecatedVariableError {
+	e := DeprecatedVariableError{Notices: make(map[string]string)}
+	metadataLock.RLock()
+	defer metadataLock.RUnlock()
+	for _, p := range report {
+		e.Keys = append(e.Keys, p.Key)
+		for key, meta := range metadata {
+			if keyName(key) == p.Key && typeName(key) == p.Type {
+				e.Notices[p.Key] = meta.Deprecated
+			}
+		}
+	}
+	return e
+}
+
+// Error implements the error interface for DeprecatedVariableError.
+func (e DeprecatedVariableError) Error() string {
+	keys := make([]string, len(e.Keys))
+	for i, key := range e.Keys {
+		keys[i] = key + " (" + e.Notices[key] + ")"
+	}
+	return "deprecated configuration variables: " + formatKeys(keys)
+}
+
+// Unwrap allows the error to be matched against ErrDeprecatedVariable.
+func (e DeprecatedVariableError) Unwrap() error {
+	return ErrDeprecatedVariable
+}
+
+var _ error = (*DeprecatedVariableError)(nil)
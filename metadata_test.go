@@ -0,0 +1,119 @@
+package configura
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadata(t *testing.T) {
+	urlKey := Describe(Variable[string]("META_DATABASE_URL"), Metadata{
+		Description: "Connection string of the primary database",
+		Example:     "postgres://localhost:5432/app",
+		Owner:       "platform",
+	})
+	tokenKey := Variable[int]("META_TOKEN")
+	Describe(tokenKey, Metadata{Description: "Token of the billing API", Sensitive: true})
+	hostKey := Variable[string]("META_HOST")
+	Describe(hostKey, Metadata{Deprecated: "use META_DATABASE_URL instead"})
+	otherKey := Variable[string]("META_OTHER")
+
+	t.Run("MetadataOf", func(t *testing.T) {
+		meta, ok := MetadataOf(urlKey)
+		require.True(t, ok)
+		assert.Equal(t, "platform", meta.Owner)
+		_, ok = MetadataOf(Variable[int]("META_DATABASE_URL"))
+		assert.False(t, ok, "Metadata should belong to the name and type of a variable")
+		_, ok = MetadataOf(otherKey)
+		assert.False(t, ok)
+	})
+
+	t.Run("MissingVariableError", func(t *testing.T) {
+		cfg := New(MapSource{})
+		err := LoadRequired(cfg, urlKey)
+		require.ErrorIs(t, err, ErrMissingVariable)
+		assert.EqualError(t, err, "missing configuration variables: META_DATABASE_URL (Connection string of the primary database)")
+		_ = LoadRequired(cfg, otherKey)
+		assert.EqualError(t, cfg.Err(), "missing configuration variables: META_DATABASE_URL (Connection string of the primary database), META_OTHER")
+		assert.EqualError(t, cfg.Exists(otherKey), "missing configuration variables: META_OTHER")
+	})
+
+	t.Run("Sensitive", func(t *testing.T) {
+		cfg := New(MapSource{string(tokenKey): "s3cr3t"})
+		assert.True(t, cfg.IsSensitive(tokenKey))
+		err := LoadStrict(cfg, tokenKey, 0)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+		assert.Equal(t, "{META_TOKEN=[REDACTED]}", fmt.Sprint(cfg))
+	})
+
+	t.Run("Deprecated", func(t *testing.T) {
+		cfg := New(MapSource{string(hostKey): "db.internal"})
+		Load(cfg, hostKey, "")
+		Load(cfg, otherKey, "")
+		deprecated := cfg.Deprecated()
+		require.Len(t, deprecated, 1)
+		assert.Equal(t, "META_HOST", deprecated[0].Key)
+
+		cfg = New(MapSource{})
+		Load(cfg, hostKey, "")
+		assert.Empty(t, cfg.Deprecated(), "A deprecated variable that is not set should not be reported")
+	})
+
+	t.Run("RejectDeprecated", func(t *testing.T) {
+		cfg := New(MapSource{string(hostKey): "db.internal"})
+		Load(cfg, hostKey, "")
+		require.NoError(t, cfg.Err(), "Deprecated variables should only be reported once rejected")
+
+		cfg.SetRejectDeprecated(true)
+		err := cfg.Err()
+		require.ErrorIs(t, err, ErrDeprecatedVariable)
+		assert.EqualError(t, err, "deprecated configuration variables: META_HOST (use META_DATABASE_URL instead)")
+		var deprecatedErr DeprecatedVariableError
+		require.ErrorAs(t, err, &deprecatedErr)
+		assert.Equal(t, []string{"META_HOST"}, deprecatedErr.Keys)
+
+		cfg.SetLayers()
+		assert.NoError(t, cfg.Err(), "A deprecated variable that is no longer set should not be reported")
+	})
+
+	t.Run("Dump", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, hostKey, "localhost")
+		assert.Equal(t, []Setting{{Key: "META_HOST", Type: "string", Value: "localhost", Origin: "fallback",
+			Deprecated: "use META_DATABASE_URL instead"}}, cfg.Dump())
+	})
+
+	t.Run("DotenvExample", func(t *testing.T) {
+		cfg := New(MapSource{})
+		_ = LoadRequired(cfg, urlKey)
+		Load(cfg, hostKey, "localhost")
+		var buf bytes.Buffer
+		require.NoError(t, cfg.DotenvExample(&buf))
+		assert.Equal(t, `# META_DATABASE_URL string (required)
+# Connection string of the primary database
+# Example: postgres://localhost:5432/app
+# Owner: platform
+META_DATABASE_URL=
+
+# META_HOST string
+# Deprecated: use META_DATABASE_URL instead
+META_HOST=localhost
+`, buf.String())
+	})
+
+	t.Run("FlagSet", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, urlKey, "")
+		Load(cfg, hostKey, "")
+		Load(cfg, otherKey, "")
+		fs := cfg.FlagSet("app", flag.ContinueOnError)
+		assert.Equal(t, "`string` Connection string of the primary database", fs.Lookup("meta-database-url").Usage)
+		assert.Equal(t, "`string` value of META_HOST (deprecated: use META_DATABASE_URL instead)", fs.Lookup("meta-host").Usage)
+		assert.Equal(t, "`string` value of META_OTHER", fs.Lookup("meta-other").Usage)
+	})
+}
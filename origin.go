@@ -0,0 +1,92 @@
+package configura
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// OriginKind describes how a variable got its value.
+type OriginKind int
+
+const (
+	// OriginFallback is the origin of a variable that took the fallback it was loaded with.
+	OriginFallback OriginKind = iota
+	// OriginSource is the origin of a variable that took its value from a layer, or from a source given to Load.
+	OriginSource
+	// OriginWrite is the origin of a variable that was written with Write.
+	OriginWrite
+	// OriginMerge is the origin of a variable that was copied from another configuration by Merge.
+	OriginMerge
+)
+
+// String returns the name of the kind, such as "fallback".
+func (k OriginKind) String() string {
+	switch k {
+	case OriginFallback:
+		return "fallback"
+	case OriginSource:
+		return "source"
+	case OriginWrite:
+		return "write"
+	case OriginMerge:
+		return "merge"
+	}
+	return "unknown"
+}
+
+// Origin records where the value of a variable came from, and when it was registered.
+type Origin struct {
+	// Kind describes how the variable got its value.
+	Kind OriginKind
+	// Source is the name of the layer or source that held the value. For a merged variable, it is the source of the
+	// value in the configuration it was merged from.
+	Source string
+	// Location is the position of the value within its source, such as "config.yaml: line 12", if the source is a
+	// ValueSource.
+	Location string
+	// Time is when the value was registered.
+	Time time.Time
+}
+
+// String describes the origin, such as "source env" or "source yaml (config.yaml: line 12)".
+func (o Origin) String() string {
+	s := o.Kind.String()
+	if o.Source != "" {
+		s += " " + o.Source
+	}
+	if o.Location != "" {
+		s += " (" + o.Location + ")"
+	}
+	return s
+}
+
+// Provenance is the origin of a single variable, as listed by Config.Provenance.
+type Provenance struct {
+	Key  string
+	Type string
+	Origin
+}
+
+// Origin returns where the value of key came from. It reports false if key is not registered in the configuration.
+func (c *Config) Origin(key any) (Origin, bool) {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+	origin, ok := c.origins[key]
+	return origin, ok
+}
+
+// Provenance returns the origin of every variable that is registered in the configuration, ordered by name and type.
+func (c *Config) Provenance() []Provenance {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+
+	report := make([]Provenance, 0, len(c.origins))
+	for key, origin := range c.origins {
+		report = append(report, Provenance{Key: keyName(key), Type: typeName(key), Origin: origin})
+	}
+	slices.SortFunc(report, func(a, b Provenance) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return report
+}
@@ -0,0 +1,117 @@
+package configura
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrigin(t *testing.T) {
+	portKey := Variable[int]("ORIGIN_PORT")
+	hostKey := Variable[string]("ORIGIN_HOST")
+	debugKey := Variable[bool]("ORIGIN_DEBUG")
+	nameKey := Variable[string]("ORIGIN_NAME")
+
+	t.Run("Kinds", func(t *testing.T) {
+		t.Setenv(string(portKey), "8080")
+		before := time.Now()
+		cfg := New(Env)
+		Load(cfg, portKey, 3000)
+		Load(cfg, hostKey, "localhost")
+		Load(cfg, debugKey, false, MapSource{string(debugKey): "true"})
+		Write(cfg, map[Variable[string]]string{nameKey: "api"})
+
+		origin, ok := cfg.Origin(portKey)
+		require.True(t, ok)
+		assert.Equal(t, OriginSource, origin.Kind)
+		assert.Equal(t, EnvLayer, origin.Source)
+		assert.False(t, origin.Time.Before(before))
+		assert.Equal(t, "source env", origin.String())
+
+		origin, _ = cfg.Origin(hostKey)
+		assert.Equal(t, OriginFallback, origin.Kind)
+		assert.Equal(t, "fallback", origin.String())
+
+		origin, _ = cfg.Origin(debugKey)
+		assert.Equal(t, "source configura.MapSource", origin.String(), "Sources given to Load should be named by their type")
+
+		origin, _ = cfg.Origin(nameKey)
+		assert.Equal(t, OriginWrite, origin.Kind)
+
+		_, ok = cfg.Origin(Variable[string]("ORIGIN_MISSING"))
+		assert.False(t, ok)
+	})
+
+	t.Run("Location", func(t *testing.T) {
+		doc, err := ParseYAML(strings.NewReader("origin:\n  port: 8080\n"), nil)
+		require.NoError(t, err)
+		cfg := New(doc)
+		Load(cfg, portKey, 3000)
+		origin, _ := cfg.Origin(portKey)
+		assert.Equal(t, "source *configura.Document (line 2)", origin.String())
+	})
+
+	t.Run("ParseErrorFallsBack", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "80a0"})
+		Load(cfg, portKey, 3000)
+		origin, _ := cfg.Origin(portKey)
+		assert.Equal(t, OriginFallback, origin.Kind)
+	})
+
+	t.Run("Layers", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "8080"})
+		cfg.AddLayer("override", MapSource{string(portKey): "9090"})
+		Load(cfg, portKey, 3000)
+		origin, _ := cfg.Origin(portKey)
+		assert.Equal(t, "override", origin.Source)
+
+		cfg.RemoveLayer("override")
+		origin, _ = cfg.Origin(portKey)
+		assert.Equal(t, "configura.MapSource", origin.Source)
+
+		Write(cfg, map[Variable[int]]int{portKey: 1})
+		cfg.Reset(portKey)
+		origin, _ = cfg.Origin(portKey)
+		assert.Equal(t, OriginSource, origin.Kind, "Reset should restore the origin of the layers underneath")
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		src := MapSource{string(hostKey): "db.internal"}
+		cfg := New(src)
+		require.NoError(t, LoadRequired(cfg, hostKey))
+		delete(src, string(hostKey))
+		require.Error(t, cfg.Refresh())
+		_, ok := cfg.Origin(hostKey)
+		assert.False(t, ok, "A missing required variable should have no origin")
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "8080"})
+		Load(cfg, portKey, 3000)
+		merged := Merge(cfg)
+		origin, ok := merged.Origin(portKey)
+		require.True(t, ok)
+		assert.Equal(t, OriginMerge, origin.Kind)
+		assert.Equal(t, "merge configura.MapSource", origin.String())
+	})
+
+	t.Run("Provenance", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "8080"})
+		Load(cfg, portKey, 3000)
+		Load(cfg, hostKey, "localhost")
+		Load(cfg, Variable[int]("ORIGIN_HOST"), 1)
+
+		report := cfg.Provenance()
+		require.Len(t, report, 3)
+		assert.Equal(t, "ORIGIN_HOST", report[0].Key)
+		assert.Equal(t, "int", report[0].Type)
+		assert.Equal(t, "ORIGIN_HOST", report[1].Key)
+		assert.Equal(t, "string", report[1].Type)
+		assert.Equal(t, "ORIGIN_PORT", report[2].Key)
+		assert.Equal(t, OriginSource, report[2].Kind)
+		assert.Equal(t, "configura.MapSource", report[2].Source)
+	})
+}
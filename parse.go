@@ -0,0 +1,128 @@
+package configura
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// parse converts a raw value into the type of key, as defined in types.go or with RegisterType. List and map values
+// are split with the separators configured on cfg. The caller must hold the lock.
+func parse[T any](cfg *Config, key Variable[T], raw string) (T, error) {
+	var zero T
+	def, ok := lookupType(reflect.TypeFor[T]())
+	if !ok {
+		return zero, fmt.Errorf("%w %s", ErrUnsupportedType, typeName(key))
+	}
+
+	value, err := def.parse(cfg, raw)
+	if err != nil {
+		return zero, err
+	}
+	return value.(T), nil
+}
+
+// format returns the raw form of value, which parse converts back into the same value. Lists and maps are joined with
+// the separators configured on cfg, and values of types that are neither built in nor registered are formatted with
+// fmt. The caller must hold the lock.
+func format(cfg *Config, value any) string {
+	if def, ok := lookupType(reflect.TypeOf(value)); ok {
+		return def.format(cfg, value)
+	}
+	return fmt.Sprint(value)
+}
+
+func formatList[T any](values []T, format func(T) string) []string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = format(v)
+	}
+	return items
+}
+
+func formatMap[T any](values map[string]T, format func(T) string) map[string]string {
+	entries := make(map[string]string, len(values))
+	for k, v := range values {
+		entries[k] = format(v)
+	}
+	return entries
+}
+
+func formatString(v string) string {
+	return v
+}
+
+func formatBytes(v []byte) string {
+	return string(v)
+}
+
+func formatRunes(v []rune) string {
+	return string(v)
+}
+
+func formatSprint[T any](v T) string {
+	return fmt.Sprint(v)
+}
+
+func formatInt64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+func formatFloat32(v float32) string {
+	return strconv.FormatFloat(float64(v), 'g', -1, 32)
+}
+
+func formatFloat64(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// typeName returns the Go type of the values held by key, as it would be written in source code.
+func typeName(key any) string {
+	switch key.(type) {
+	case Variable[[]byte]:
+		return "[]byte"
+	case Variable[[]rune]:
+		return "[]rune"
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fmt.Sprintf("%T", key), "configura.Variable["), "]")
+}
+
+func parseString(raw string) (string, error) {
+	return raw, nil
+}
+
+func parseInt[T ~int8 | ~int16 | ~int32](raw string, bitSize int) (T, error) {
+	v, err := strconv.ParseInt(raw, 10, bitSize)
+	return T(v), err
+}
+
+func parseInt64(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
+
+func parseUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uintptr](raw string, bitSize int) (T, error) {
+	v, err := strconv.ParseUint(raw, 10, bitSize)
+	return T(v), err
+}
+
+func parseUint64(raw string) (uint64, error) {
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+func parseBytes(raw string) ([]byte, error) {
+	return []byte(raw), nil
+}
+
+func parseRunes(raw string) ([]rune, error) {
+	return []rune(raw), nil
+}
+
+func parseFloat32(raw string) (float32, error) {
+	v, err := strconv.ParseFloat(raw, 32)
+	return float32(v), err
+}
+
+func parseFloat64(raw string) (float64, error) {
+	return strconv.ParseFloat(raw, 64)
+}
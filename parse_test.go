@@ -0,0 +1,133 @@
+package configura
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cfg := New()
+
+	t.Run("Valid", func(t *testing.T) {
+		assertParsed(t, cfg, Variable[string]("K"), "text", "text")
+		assertParsed(t, cfg, Variable[int]("K"), "-42", -42)
+		assertParsed(t, cfg, Variable[int8]("K"), "-8", int8(-8))
+		assertParsed(t, cfg, Variable[int16]("K"), "16", int16(16))
+		assertParsed(t, cfg, Variable[int32]("K"), "32", int32(32))
+		assertParsed(t, cfg, Variable[int64]("K"), "64", int64(64))
+		assertParsed(t, cfg, Variable[uint]("K"), "1", uint(1))
+		assertParsed(t, cfg, Variable[uint8]("K"), "8", uint8(8))
+		assertParsed(t, cfg, Variable[uint16]("K"), "16", uint16(16))
+		assertParsed(t, cfg, Variable[uint32]("K"), "32", uint32(32))
+		assertParsed(t, cfg, Variable[uint64]("K"), "64", uint64(64))
+		assertParsed(t, cfg, Variable[uintptr]("K"), "4096", uintptr(4096))
+		assertParsed(t, cfg, Variable[[]byte]("K"), "bytes", []byte("bytes"))
+		assertParsed(t, cfg, Variable[[]rune]("K"), "runes", []rune("runes"))
+		assertParsed(t, cfg, Variable[float32]("K"), "1.5", float32(1.5))
+		assertParsed(t, cfg, Variable[float64]("K"), "2.5", 2.5)
+		assertParsed(t, cfg, Variable[bool]("K"), "true", true)
+		assertParsed(t, cfg, Variable[time.Duration]("K"), "1m", time.Minute)
+		assertParsed(t, cfg, Variable[[]string]("K"), "a,b", []string{"a", "b"})
+		assertParsed(t, cfg, Variable[[]int]("K"), "1,2", []int{1, 2})
+		assertParsed(t, cfg, Variable[[]float64]("K"), "0.5", []float64{0.5})
+		assertParsed(t, cfg, Variable[[]bool]("K"), "true,false", []bool{true, false})
+		assertParsed(t, cfg, Variable[map[string]string]("K"), "a=b", map[string]string{"a": "b"})
+		assertParsed(t, cfg, Variable[map[string]int]("K"), "a=1", map[string]int{"a": 1})
+		assertParsed(t, cfg, Variable[map[string]int64]("K"), "a=1", map[string]int64{"a": 1})
+		assertParsed(t, cfg, Variable[map[string]float64]("K"), "a=0.5", map[string]float64{"a": 0.5})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := parse(cfg, Variable[int]("K"), "80a0")
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		_, err = parse(cfg, Variable[int8]("K"), "300")
+		assert.ErrorIs(t, err, strconv.ErrRange)
+		_, err = parse(cfg, Variable[uint]("K"), "-1")
+		assert.Error(t, err)
+		_, err = parse(cfg, Variable[time.Duration]("K"), "30")
+		assert.Error(t, err)
+		_, err = parse(cfg, Variable[[]int]("K"), "1,x")
+		assert.Error(t, err)
+		_, err = parse(cfg, Variable[map[string]int]("K"), "a")
+		assert.Error(t, err)
+	})
+
+	t.Run("UsesConfiguredSeparators", func(t *testing.T) {
+		custom := New()
+		custom.SetListSeparator("|")
+		require.NoError(t, custom.SetMapSeparators(";", ":"))
+		assertParsed(t, custom, Variable[[]string]("K"), "a,b|c", []string{"a,b", "c"})
+		assertParsed(t, custom, Variable[map[string]string]("K"), "a:1;b:2", map[string]string{"a": "1", "b": "2"})
+	})
+}
+
+func assertParsed[T any](t *testing.T, cfg *Config, key Variable[T], raw string, expected T) {
+	t.Helper()
+	value, err := parse(cfg, key, raw)
+	require.NoError(t, err)
+	assert.Equal(t, expected, value)
+}
+
+func TestTypeName(t *testing.T) {
+	testCases := []struct {
+		key      any
+		expected string
+	}{
+		{Variable[string]("K"), "string"},
+		{Variable[uintptr]("K"), "uintptr"},
+		{Variable[[]byte]("K"), "[]byte"},
+		{Variable[[]rune]("K"), "[]rune"},
+		{Variable[time.Duration]("K"), "time.Duration"},
+		{Variable[[]string]("K"), "[]string"},
+		{Variable[map[string]float64]("K"), "map[string]float64"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, typeName(tc.key))
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cfg := New()
+
+	assertFormatted(t, cfg, Variable[string]("K"), "text", "text")
+	assertFormatted(t, cfg, Variable[int]("K"), -42, "-42")
+	assertFormatted(t, cfg, Variable[uint8]("K"), 8, "8")
+	assertFormatted(t, cfg, Variable[[]byte]("K"), []byte("bytes"), "bytes")
+	assertFormatted(t, cfg, Variable[[]rune]("K"), []rune("runes"), "runes")
+	assertFormatted(t, cfg, Variable[float32]("K"), 1.5, "1.5")
+	assertFormatted(t, cfg, Variable[float64]("K"), 0.1, "0.1")
+	assertFormatted(t, cfg, Variable[bool]("K"), true, "true")
+	assertFormatted(t, cfg, Variable[time.Duration]("K"), 90*time.Second, "1m30s")
+	assertFormatted(t, cfg, Variable[[]string]("K"), []string{"a,b", "c"}, `"a,b",c`)
+	assertFormatted(t, cfg, Variable[[]int]("K"), []int{1, 2}, "1,2")
+	assertFormatted(t, cfg, Variable[[]float64]("K"), []float64{0.5}, "0.5")
+	assertFormatted(t, cfg, Variable[[]bool]("K"), []bool{true, false}, "true,false")
+	assertFormatted(t, cfg, Variable[map[string]string]("K"), map[string]string{"b": "2", "a": "1"}, "a=1,b=2")
+	assertFormatted(t, cfg, Variable[map[string]int]("K"), map[string]int{"a": 1}, "a=1")
+	assertFormatted(t, cfg, Variable[map[string]int64]("K"), map[string]int64{"a": 1}, "a=1")
+	assertFormatted(t, cfg, Variable[map[string]float64]("K"), map[string]float64{"a": 0.5}, "a=0.5")
+
+	t.Run("UsesConfiguredSeparators", func(t *testing.T) {
+		custom := New()
+		custom.SetListSeparator(";")
+		require.NoError(t, custom.SetMapSeparators(";", ":"))
+		assertFormatted(t, custom, Variable[[]string]("K"), []string{"a,b", "c"}, "a,b;c")
+		assertFormatted(t, custom, Variable[map[string]string]("K"), map[string]string{"a": "1", "b": "2"}, "a:1;b:2")
+	})
+}
+
+// assertFormatted checks that value is formatted as expected, and that parse turns it back into value.
+func assertFormatted[T any](t *testing.T, cfg *Config, key Variable[T], value T, expected string) {
+	t.Helper()
+	raw := format(cfg, value)
+	assert.Equal(t, expected, raw)
+	parsed, err := parse(cfg, key, raw)
+	require.NoError(t, err)
+	assert.Equal(t, value, parsed)
+}
@@ -0,0 +1,266 @@
+package configura
+
+import (
+	"bufio"
+	"cmp"
+	"html/template"
+	"io"
+	"net/url"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// VariableDoc documents a variable, as listed by Config.Reference.
+type VariableDoc struct {
+	Key  string
+	Type string
+	// Fallback is the fallback of the variable in its raw form. It is empty for required and sensitive variables, and
+	// for variables that were never loaded.
+	Fallback  string
+	Required  bool
+	Sensitive bool
+	// Description, Example, Owner and Deprecated are taken from the metadata attached with Describe.
+	Description string
+	Example     string
+	Owner       string
+	Deprecated  string
+	// RequiredBy lists the packages that called Config.Exists with the variable, in alphabetical order.
+	RequiredBy []string
+}
+
+// Reference documents every variable that was declared through Load, LoadStrict or LoadRequired, that is registered in
+// the configuration, or that was passed to Exists, ordered by name and type. Unlike Dump, it describes the variables
+// rather than their current values, so its output only changes along with the code.
+func (c *Config) Reference() []VariableDoc {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+
+	docs := make(map[any]*VariableDoc)
+	doc := func(key any) *VariableDoc {
+		if d, ok := docs[key]; ok {
+			return d
+		}
+		meta, _ := MetadataOf(key)
+		d := &VariableDoc{
+			Key:         keyName(key),
+			Type:        typeName(key),
+			Sensitive:   c.isSensitive(key),
+			Description: meta.Description,
+			Example:     meta.Example,
+			Owner:       meta.Owner,
+			Deprecated:  meta.Deprecated,
+		}
+		docs[key] = d
+		return d
+	}
+
+	for key, decl := range c.declarations {
+		d := doc(key)
+		fallback, ok := decl.fallbackValue()
+		d.Required = !ok
+		if ok && !d.Sensitive {
+			d.Fallback = format(c, fallback)
+		}
+	}
+	for _, key := range c.keys() {
+		doc(key)
+	}
+	for key, pkgs := range c.requiredBy {
+		d := doc(key)
+		d.RequiredBy = slices.Sorted(slices.Values(pkgs))
+	}
+
+	reference := make([]VariableDoc, 0, len(docs))
+	for _, d := range docs {
+		reference = append(reference, *d)
+	}
+	slices.SortFunc(reference, func(a, b VariableDoc) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return reference
+}
+
+// ReferenceMarkdown writes the variables listed by Reference to w as a Markdown table, which can be pasted into a
+// README and compared with a golden file.
+func (c *Config) ReferenceMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("| Variable | Type | Fallback | Required | Sensitive | Description | Required by |\n")
+	bw.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, d := range c.Reference() {
+		requiredBy := make([]string, len(d.RequiredBy))
+		for i, pkg := range d.RequiredBy {
+			requiredBy[i] = markdownCode(pkg)
+		}
+		cells := []string{
+			markdownCode(d.Key),
+			markdownCode(d.Type),
+			markdownCode(d.Fallback),
+			yesNo(d.Required),
+			yesNo(d.Sensitive),
+			strings.Join(markdownDetails(d), "<br>"),
+			strings.Join(requiredBy, "<br>"),
+		}
+		bw.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return bw.Flush()
+}
+
+// ReferenceHTML writes the variables listed by Reference to w as an HTML table.
+func (c *Config) ReferenceHTML(w io.Writer) error {
+	return referenceTemplate.Execute(w, c.Reference())
+}
+
+var referenceTemplate = template.Must(template.New("reference").Funcs(template.FuncMap{
+	"yesNo": yesNo,
+}).Parse(`<table>
+  <thead>
+    <tr><th>Variable</th><th>Type</th><th>Fallback</th><th>Required</th><th>Sensitive</th><th>Description</th><th>Required by</th></tr>
+  </thead>
+  <tbody>
+{{- range .}}
+    <tr>
+      <td><code>{{.Key}}</code></td>
+      <td><code>{{.Type}}</code></td>
+      <td>{{if .Fallback}}<code>{{.Fallback}}</code>{{end}}</td>
+      <td>{{yesNo .Required}}</td>
+      <td>{{yesNo .Sensitive}}</td>
+      <td>
+        {{- .Description}}
+        {{- if .Example}}{{if .Description}}<br>{{end}}Example: <code>{{.Example}}</code>{{end}}
+        {{- if .Owner}}{{if or .Description .Example}}<br>{{end}}Owner: {{.Owner}}{{end}}
+        {{- if .Deprecated}}{{if or .Description .Example .Owner}}<br>{{end}}<strong>Deprecated:</strong> {{.Deprecated}}{{end -}}
+      </td>
+      <td>{{range $i, $pkg := .RequiredBy}}{{if $i}}<br>{{end}}<code>{{$pkg}}</code>{{end}}</td>
+    </tr>
+{{- end}}
+  </tbody>
+</table>
+`))
+
+// markdownDetails returns the lines of the description cell of d, holding its description, example, owner and
+// deprecation notice if they are set.
+func markdownDetails(d VariableDoc) []string {
+	var lines []string
+	if d.Description != "" {
+		lines = append(lines, markdownText(d.Description))
+	}
+	if d.Example != "" {
+		lines = append(lines, "Example: "+markdownCode(d.Example))
+	}
+	if d.Owner != "" {
+		lines = append(lines, "Owner: "+markdownText(d.Owner))
+	}
+	if d.Deprecated != "" {
+		lines = append(lines, "**Deprecated:** "+markdownText(d.Deprecated))
+	}
+	return lines
+}
+
+// markdownText escapes text for a cell of a Markdown table.
+func markdownText(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(text)
+}
+
+// markdownCode formats text as inline code within a cell of a Markdown table, or returns an empty string if text is
+// empty.
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	text = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(text)
+	if strings.Contains(text, "`") {
+		return "`` " + text + " ``"
+	}
+	return "`" + text + "`"
+}
+
+// yesNo returns "yes" or "no".
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// requireBy records that pkg requires key. The caller must hold the write lock.
+func (c *Config) requireBy(key any, pkgs ...string) {
+	if c.requiredBy == nil {
+		c.requiredBy = make(map[any][]string)
+	}
+	for _, pkg := range pkgs {
+		if pkg != "" && !slices.Contains(c.requiredBy[key], pkg) {
+			c.requiredBy[key] = append(c.requiredBy[key], pkg)
+		}
+	}
+}
+
+// requireByCaller records that the package of the function that called Exists requires keys. The write lock is only
+// taken the first time a package requires a key, so that repeated calls of Exists do not block each other.
+func (c *Config) requireByCaller(keys []any) {
+	pkg := callerPackage(2)
+	if pkg == "" {
+		return
+	}
+
+	var pending []any
+	for _, key := range keys {
+		if _, ok := c.requiredSeen.Load(requirement{key: key, pkg: pkg}); !ok {
+			pending = append(pending, key)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	for _, key := range pending {
+		c.requireBy(key, pkg)
+		c.requiredSeen.Store(requirement{key: key, pkg: pkg}, struct{}{})
+	}
+}
+
+// requirement is a variable required by a package through Exists.
+type requirement struct {
+	key any
+	pkg string
+}
+
+// callerPackages caches the package of the functions that called Exists, by program counter.
+var callerPackages sync.Map
+
+// callerPackage returns the import path of the package of the function that called the function skip frames up the
+// stack, such as "github.com/org/service/billing", or an empty string if it cannot be determined.
+func callerPackage(skip int) string {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return ""
+	}
+	if pkg, ok := callerPackages.Load(pcs[0]); ok {
+		return pkg.(string)
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	pkg := functionPackage(frame.Function)
+	callerPackages.Store(pcs[0], pkg)
+	return pkg
+}
+
+// functionPackage returns the import path of the package of the function named function, as reported by the runtime.
+// The name of a function is its package path, followed by a dot and the name of the function, such as
+// github.com/org/service/billing.(*Service).Init.func1. Any element of the path may contain dots, but the runtime
+// escapes those of the last element, so that gopkg.in/yaml.v3 is reported as gopkg.in/yaml%2ev3. The first dot after
+// the last slash therefore ends the path, which is then unescaped.
+func functionPackage(function string) string {
+	pkg := function
+	slash := strings.LastIndex(pkg, "/") + 1
+	if dot := strings.Index(pkg[slash:], "."); dot >= 0 {
+		pkg = pkg[:slash+dot]
+	}
+	if unescaped, err := url.PathUnescape(pkg); err == nil {
+		pkg = unescaped
+	}
+	return pkg
+}
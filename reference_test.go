@@ -0,0 +1,111 @@
+package configura
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReference(t *testing.T) {
+	urlKey := Describe(Variable[string]("REF_DATABASE_URL"), Metadata{
+		Description: "Connection string of the primary database",
+		Example:     "postgres://localhost:5432/app",
+		Owner:       "platform | data",
+	})
+	portKey := Describe(Variable[int]("REF_PORT"), Metadata{Description: "Port of the HTTP server"})
+	timeoutKey := Describe(Variable[time.Duration]("REF_TIMEOUT"), Metadata{Deprecated: "use REF_DEADLINE instead"})
+	passwordKey := Variable[string]("REF_PASSWORD")
+	tagsKey := Variable[[]string]("REF_TAGS")
+
+	newConfig := func() *Config {
+		cfg := New(MapSource{string(portKey): "8080"})
+		cfg.MarkSensitive(passwordKey)
+		_ = LoadRequired(cfg, urlKey)
+		Load(cfg, portKey, 3000)
+		Load(cfg, timeoutKey, 30*time.Second)
+		Load(cfg, passwordKey, "changeme")
+		func() {
+			_ = cfg.Exists(portKey, tagsKey)
+		}()
+		return cfg
+	}
+
+	t.Run("Reference", func(t *testing.T) {
+		pkg := "github.com/Kansuler/configura"
+		assert.Equal(t, []VariableDoc{
+			{Key: "REF_DATABASE_URL", Type: "string", Required: true, Description: "Connection string of the primary database",
+				Example: "postgres://localhost:5432/app", Owner: "platform | data"},
+			{Key: "REF_PASSWORD", Type: "string", Sensitive: true},
+			{Key: "REF_PORT", Type: "int", Fallback: "3000", Description: "Port of the HTTP server", RequiredBy: []string{pkg}},
+			{Key: "REF_TAGS", Type: "[]string", RequiredBy: []string{pkg}},
+			{Key: "REF_TIMEOUT", Type: "time.Duration", Fallback: "30s", Deprecated: "use REF_DEADLINE instead"},
+		}, newConfig().Reference(), "The fallbacks should be listed rather than the values that were loaded")
+	})
+
+	t.Run("RecordedOnce", func(t *testing.T) {
+		cfg := newConfig()
+		for range 3 {
+			_ = cfg.Exists(portKey)
+		}
+		assert.Equal(t, []string{"github.com/Kansuler/configura"}, cfg.requiredBy[portKey])
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		merged := Merge(newConfig(), newConfig())
+		assert.Equal(t, newConfig().Reference(), merged.Reference())
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newConfig().ReferenceMarkdown(&buf))
+		assert.Equal(t, "| Variable | Type | Fallback | Required | Sensitive | Description | Required by |\n"+
+			"| --- | --- | --- | --- | --- | --- | --- |\n"+
+			"| `REF_DATABASE_URL` | `string` |  | yes | no | Connection string of the primary database<br>"+
+			"Example: `postgres://localhost:5432/app`<br>Owner: platform \\| data |  |\n"+
+			"| `REF_PASSWORD` | `string` |  | no | yes |  |  |\n"+
+			"| `REF_PORT` | `int` | `3000` | no | no | Port of the HTTP server | `github.com/Kansuler/configura` |\n"+
+			"| `REF_TAGS` | `[]string` |  | no | no |  | `github.com/Kansuler/configura` |\n"+
+			"| `REF_TIMEOUT` | `time.Duration` | `30s` | no | no | **Deprecated:** use REF_DEADLINE instead |  |\n",
+			buf.String())
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, newConfig().ReferenceHTML(&buf))
+		html := buf.String()
+		assert.Contains(t, html, "<td><code>REF_DATABASE_URL</code></td>")
+		assert.Contains(t, html, "<td>Connection string of the primary database<br>Example: <code>postgres://localhost:5432/app</code><br>Owner: platform | data</td>")
+		assert.Contains(t, html, "<td><strong>Deprecated:</strong> use REF_DEADLINE instead</td>")
+		assert.Contains(t, html, "<td><code>github.com/Kansuler/configura</code></td>")
+		assert.NotContains(t, html, "changeme")
+
+		var again bytes.Buffer
+		require.NoError(t, newConfig().ReferenceHTML(&again))
+		assert.Equal(t, html, again.String(), "The output should be stable")
+	})
+
+	t.Run("Escaping", func(t *testing.T) {
+		assert.Equal(t, "`a\\|b`", markdownCode("a|b"))
+		assert.Equal(t, "`` a`b ``", markdownCode("a`b"))
+		assert.Equal(t, "a<br>b", markdownText("a\nb"))
+	})
+}
+
+func TestFunctionPackage(t *testing.T) {
+	testCases := []struct {
+		function string
+		expected string
+	}{
+		{"main.main", "main"},
+		{"github.com/org/service/billing.(*Service).Init.func1", "github.com/org/service/billing"},
+		{"gopkg.in/yaml%2ev3.Marshal", "gopkg.in/yaml.v3"},
+		{"example.com/a.b/c%2ed.F", "example.com/a.b/c.d"},
+		{"github.com/org/service.Load[...]", "github.com/org/service"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, functionPackage(tc.function), tc.function)
+	}
+}
@@ -0,0 +1,65 @@
+package configura
+
+import "maps"
+
+// registry holds the value of every variable of a configuration, keyed by the variable itself, so that variables with
+// the same name but different types are kept apart. A registry that was published through Config.current is never
+// modified again, so getters can read it without taking any lock. Changes are made to a draft copy, which is published
+// when the write lock is released.
+type registry struct {
+	values map[any]any
+}
+
+// newRegistry returns an empty registry.
+func newRegistry() *registry {
+	return &registry{values: make(map[any]any)}
+}
+
+// clone returns a copy of r that can be modified without affecting r.
+func (r *registry) clone() *registry {
+	return &registry{values: maps.Clone(r.values)}
+}
+
+// emptyRegistry stands in for the registry of a zero Config, which was not created with New. It is never modified.
+var emptyRegistry = newRegistry()
+
+// registry returns the published registry of the configuration, which must not be modified.
+func (c *Config) registry() *registry {
+	if r := c.current.Load(); r != nil {
+		return r
+	}
+	return emptyRegistry
+}
+
+// view returns the registry that holds the latest values, which is the draft while the configuration is being changed.
+// The caller must hold the lock.
+func (c *Config) view() *registry {
+	if c.draft != nil {
+		return c.draft
+	}
+	return c.registry()
+}
+
+// mutable returns the draft registry, copying it from the published one on the first change since the write lock was
+// taken. The caller must hold the write lock, and release it with unlock so that the draft is published.
+func (c *Config) mutable() *registry {
+	if c.draft == nil {
+		c.draft = c.registry().clone()
+	}
+	return c.draft
+}
+
+// publish makes the draft registry, if any, visible to getters. The caller must hold the write lock.
+func (c *Config) publish() {
+	if c.draft != nil {
+		c.current.Store(c.draft)
+		c.draft = nil
+	}
+}
+
+// Get returns the value of key, or the zero value of T if key is not registered. It works for variables of every
+// type, including those registered with RegisterType, and never takes a lock.
+func Get[T any](cfg *Config, key Variable[T]) T {
+	value, _ := cfg.registry().values[key].(T)
+	return value
+}
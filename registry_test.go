@@ -0,0 +1,159 @@
+package configura
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistrySnapshots(t *testing.T) {
+	key := Variable[int]("SNAPSHOT_PORT")
+	cfg := New(MapSource{})
+	Load(cfg, key, 3000)
+
+	published := cfg.registry()
+	Write(cfg, map[Variable[int]]int{key: 8080})
+	assert.Equal(t, 3000, published.values[key], "A published registry should never be modified")
+	assert.Equal(t, 8080, cfg.Int(key))
+	assert.Nil(t, cfg.draft, "The draft should be published when the lock is released")
+
+	unchanged := cfg.registry()
+	cfg.SetListSeparator(";")
+	assert.Same(t, unchanged, cfg.registry(), "Changes that leave the values alone should not publish a registry")
+}
+
+func TestZeroConfig(t *testing.T) {
+	var cfg Config
+	assert.Equal(t, "", cfg.String("ZERO_HOST"))
+	assert.Equal(t, 0, cfg.Int("ZERO_PORT"))
+	assert.Nil(t, Get(&cfg, Variable[[]string]("ZERO_HOSTS")))
+	assert.ErrorIs(t, cfg.Exists(Variable[int]("ZERO_PORT")), ErrMissingVariable)
+	assert.NoError(t, cfg.Err())
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	key := Variable[int]("SNAPSHOT_PORT")
+	cfg := New(MapSource{})
+	Load(cfg, key, 0)
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				Write(cfg, map[Variable[int]]int{key: i*100 + j})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				_ = cfg.Int(key)
+				_ = cfg.Exists(key)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// rwMutexConfig is the way values were read before snapshots, with a read lock around every lookup, as a baseline for
+// the benchmarks.
+type rwMutexConfig struct {
+	mu     sync.RWMutex
+	values map[Variable[string]]string
+}
+
+func (c *rwMutexConfig) String(key Variable[string]) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.values[key]
+}
+
+func benchmarkConfig(b *testing.B) (*Config, []Variable[string]) {
+	b.Helper()
+	cfg := New(MapSource{})
+	keys := make([]Variable[string], 64)
+	for i := range keys {
+		keys[i] = Variable[string]("BENCH_" + strconv.Itoa(i))
+		Load(cfg, keys[i], "value")
+	}
+	return cfg, keys
+}
+
+func BenchmarkString(b *testing.B) {
+	cfg, keys := benchmarkConfig(b)
+	for i := 0; b.Loop(); i++ {
+		_ = cfg.String(keys[i%len(keys)])
+	}
+}
+
+func BenchmarkStringParallel(b *testing.B) {
+	cfg, keys := benchmarkConfig(b)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_ = cfg.String(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkExistsParallel(b *testing.B) {
+	cfg, keys := benchmarkConfig(b)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_ = cfg.Exists(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkStringParallelRWMutex(b *testing.B) {
+	_, keys := benchmarkConfig(b)
+	cfg := &rwMutexConfig{values: make(map[Variable[string]]string)}
+	for _, key := range keys {
+		cfg.values[key] = "value"
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_ = cfg.String(keys[i%len(keys)])
+		}
+	})
+}
+
+func BenchmarkStringParallelWhileWriting(b *testing.B) {
+	cfg, keys := benchmarkConfig(b)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+				Write(cfg, map[Variable[string]]string{keys[i%len(keys)]: strconv.Itoa(i)})
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			_ = cfg.String(keys[i%len(keys)])
+		}
+	})
+	b.StopTimer()
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkWrite(b *testing.B) {
+	cfg, keys := benchmarkConfig(b)
+	for i := 0; b.Loop(); i++ {
+		Write(cfg, map[Variable[string]]string{keys[i%len(keys)]: "value"})
+	}
+}
@@ -0,0 +1,251 @@
+package configura
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+)
+
+// FileSource is a Source backed by a file, which Config.Reload and Config.Watch read again when the file changes.
+type FileSource struct {
+	path string
+	read func(path string) (Source, error)
+
+	mu      sync.RWMutex
+	version fileVersion
+}
+
+// fileVersion is the content of a file as it was read, along with what tells whether the file was modified since.
+type fileVersion struct {
+	src     Source
+	modTime time.Time
+	size    int64
+}
+
+// File reads the file at path with read, such as a function that calls YAMLFile, and returns a FileSource that reads
+// it again whenever the configuration is reloaded after the file was modified.
+func File(path string, read func(path string) (Source, error)) (*FileSource, error) {
+	f := &FileSource{path: path, read: read}
+	v, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	f.version = v
+	return f, nil
+}
+
+// Lookup looks up key in the last version of the file that was read.
+func (f *FileSource) Lookup(key string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.version.src.Lookup(key)
+}
+
+// LookupValue looks up key in the last version of the file that was read, keeping the type of its value if the file
+// was read into a ValueSource.
+func (f *FileSource) LookupValue(key string) (Value, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if src, ok := f.version.src.(ValueSource); ok {
+		return src.LookupValue(key)
+	}
+	raw, ok := f.version.src.Lookup(key)
+	return Value{Data: raw}, ok
+}
+
+// Name returns the path of the file.
+func (f *FileSource) Name() string {
+	return f.path
+}
+
+// changed reads the file again if it was modified since it was last read, and returns the new version of it, or nil
+// if it was not modified.
+func (f *FileSource) changed() (*fileVersion, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	modified := !info.ModTime().Equal(f.version.modTime) || info.Size() != f.version.size
+	f.mu.RUnlock()
+	if !modified {
+		return nil, nil
+	}
+	v, err := f.load()
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// load reads the file.
+func (f *FileSource) load() (fileVersion, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	src, err := f.read(f.path)
+	if err != nil {
+		return fileVersion{}, err
+	}
+	return fileVersion{src: src, modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// swap replaces the version of the file that was last read with v, and returns the one it replaced.
+func (f *FileSource) swap(v fileVersion) fileVersion {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	old := f.version
+	f.version = v
+	return old
+}
+
+// Change describes a variable whose value changed when the configuration was reloaded. Old is nil if the variable was
+// not registered before, and New is nil if it no longer is. The values of sensitive variables are Redacted.
+type Change struct {
+	Key  string
+	Type string
+	Old  any
+	New  any
+}
+
+// Reload reads every FileSource among the layers of the configuration again if its file was modified, and resolves
+// every loaded variable again. The new versions of the files and the values that follow from them replace the old
+// ones at once, so readers see either the old or the new configuration. If any file cannot be read, the configuration
+// is left untouched and the error is returned. Reload returns the variables whose value changed, ordered by name.
+func (c *Config) Reload() ([]Change, error) {
+	return c.reload(false, false)
+}
+
+// reload reads the files of the configuration again and resolves every loaded variable, if any file was modified or
+// always is true. If validate is true, the configuration is left as it was unless every required variable is set and
+// every value parses, and the failures are returned otherwise.
+func (c *Config) reload(always, validate bool) ([]Change, error) {
+	c.rwLock.RLock()
+	var files []*FileSource
+	for _, layer := range c.layers {
+		if f, ok := layer.Source.(*FileSource); ok {
+			files = append(files, f)
+		}
+	}
+	c.rwLock.RUnlock()
+
+	versions := make(map[*FileSource]fileVersion)
+	var errs []error
+	for _, f := range files {
+		v, err := f.changed()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.path, err))
+		} else if v != nil {
+			versions[f] = *v
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(versions) == 0 && !always {
+		return nil, nil
+	}
+
+	defer c.unlock(c.lock())
+	before := c.snapshot()
+	origins, parseErrors := maps.Clone(c.origins), slices.Clone(c.parseErrors)
+	for f, v := range versions {
+		versions[f] = f.swap(v)
+	}
+
+	parseErrs := c.refresh()
+	if validate {
+		err := c.err()
+		if err == nil && len(parseErrs) > 0 {
+			err = InvalidVariableError{Errors: parseErrs}
+		}
+		if err != nil {
+			for f, v := range versions {
+				f.swap(v)
+			}
+			for key, decl := range c.declarations {
+				value, ok := before[key]
+				decl.restore(c, value, ok)
+			}
+			c.origins, c.parseErrors = origins, parseErrors
+			return nil, err
+		}
+	}
+	return c.changes(before), nil
+}
+
+// Watch polls the files of every FileSource among the layers of the configuration at interval, and reloads the
+// configuration with Reload when any of them was modified, until ctx is done. onReload, if not nil, is called after
+// every reload that changed a variable, and after every reload that failed.
+func (c *Config) Watch(ctx context.Context, interval time.Duration, onReload func(changes []Change, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changes, err := c.Reload()
+			if onReload != nil && (err != nil || len(changes) > 0) {
+				onReload(changes, err)
+			}
+		}
+	}
+}
+
+// snapshot returns the value of every registered variable. The caller must hold the lock.
+func (c *Config) snapshot() map[any]any {
+	values := make(map[any]any)
+	for _, key := range c.keys() {
+		values[key], _ = c.value(key)
+	}
+	return values
+}
+
+// changes compares the registered variables with the values of an earlier snapshot. The caller must hold the lock.
+func (c *Config) changes(before map[any]any) []Change {
+	var changes []Change
+	for key, old := range before {
+		value, ok := c.value(key)
+		if !ok {
+			changes = append(changes, c.change(key, old, nil))
+		} else if !reflect.DeepEqual(old, value) {
+			changes = append(changes, c.change(key, old, value))
+		}
+	}
+	for _, key := range c.keys() {
+		if _, ok := before[key]; !ok {
+			value, _ := c.value(key)
+			changes = append(changes, c.change(key, nil, value))
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return changes
+}
+
+// change returns the Change of key from old to new, with the values replaced with Redacted if key is sensitive. The
+// caller must hold the lock.
+func (c *Config) change(key, old, new any) Change {
+	if c.isSensitive(key) {
+		if old != nil {
+			old = Redacted
+		}
+		if new != nil {
+			new = Redacted
+		}
+	}
+	return Change{Key: keyName(key), Type: typeName(key), Old: old, New: new}
+}
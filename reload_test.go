@@ -0,0 +1,164 @@
+package configura
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readYAML(path string) (Source, error) {
+	return YAMLFile(path, nil)
+}
+
+// writeFile writes content to path, and moves its modification time forward so that the change is noticed even on
+// file systems with a coarse resolution.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	if !modTime.IsZero() {
+		require.NoError(t, os.Chtimes(path, modTime.Add(time.Second), modTime.Add(time.Second)))
+	}
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "port: 8080\n")
+
+	f, err := File(path, readYAML)
+	require.NoError(t, err)
+	assert.Equal(t, path, SourceName(f))
+	raw, ok := f.Lookup("PORT")
+	assert.True(t, ok)
+	assert.Equal(t, "8080", raw)
+	value, ok := f.LookupValue("PORT")
+	assert.True(t, ok)
+	assert.Equal(t, path+": line 1", value.Location)
+
+	dotenv, err := File(filepath.Join(t.TempDir(), "missing.env"), func(path string) (Source, error) { return DotenvFile(path) })
+	assert.Error(t, err)
+	assert.Nil(t, dotenv)
+}
+
+func TestReload(t *testing.T) {
+	portKey := Variable[int]("PORT")
+	levelKey := Variable[string]("LOG_LEVEL")
+	limitKey := Variable[int]("RATE_LIMIT")
+
+	newConfig := func(t *testing.T) (*Config, string) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeFile(t, path, "port: 8080\nlog_level: info\n")
+		f, err := File(path, readYAML)
+		require.NoError(t, err)
+		cfg := New(f)
+		Load(cfg, portKey, 3000)
+		Load(cfg, levelKey, "warn")
+		Load(cfg, limitKey, 100)
+		return cfg, path
+	}
+
+	t.Run("Changes", func(t *testing.T) {
+		cfg, path := newConfig(t)
+		changes, err := cfg.Reload()
+		require.NoError(t, err)
+		assert.Empty(t, changes, "Nothing should change while the file is not modified")
+
+		writeFile(t, path, "port: 8080\nlog_level: debug\nrate_limit: 50\n")
+		changes, err = cfg.Reload()
+		require.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Key: "LOG_LEVEL", Type: "string", Old: "info", New: "debug"},
+			{Key: "RATE_LIMIT", Type: "int", Old: 100, New: 50},
+		}, changes)
+		assert.Equal(t, "debug", cfg.String(levelKey))
+		assert.Equal(t, 50, cfg.Int(limitKey))
+
+		writeFile(t, path, "port: 8080\n")
+		changes, err = cfg.Reload()
+		require.NoError(t, err)
+		assert.Len(t, changes, 2)
+		assert.Equal(t, "warn", cfg.String(levelKey), "Removed values should fall back")
+	})
+
+	t.Run("WrittenValuesAreKept", func(t *testing.T) {
+		cfg, path := newConfig(t)
+		Write(cfg, map[Variable[string]]string{levelKey: "error"})
+		writeFile(t, path, "port: 8080\nlog_level: debug\n")
+		changes, err := cfg.Reload()
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, "error", cfg.String(levelKey))
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		cfg, path := newConfig(t)
+		writeFile(t, path, "port: [8080\n")
+		changes, err := cfg.Reload()
+		assert.ErrorContains(t, err, path)
+		assert.Nil(t, changes)
+		assert.Equal(t, 8080, cfg.Int(portKey), "A file that cannot be read should leave the configuration untouched")
+		assert.Equal(t, "info", cfg.String(levelKey))
+
+		require.NoError(t, os.Remove(path))
+		_, err = cfg.Reload()
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("RequiredBecomesMissing", func(t *testing.T) {
+		cfg, path := newConfig(t)
+		require.ErrorIs(t, LoadRequired(cfg, Variable[string]("LOG_FORMAT")), ErrMissingVariable)
+		writeFile(t, path, "port: 8080\nlog_format: json\n")
+		_, err := cfg.Reload()
+		require.NoError(t, err)
+		assert.NoError(t, cfg.Err(), "A required variable should be found after it was added to the file")
+		assert.Equal(t, "json", cfg.String("LOG_FORMAT"))
+
+		writeFile(t, path, "port: 8080\n")
+		changes, err := cfg.Reload()
+		require.NoError(t, err)
+		assert.Contains(t, changes, Change{Key: "LOG_FORMAT", Type: "string", Old: "json"})
+		assert.ErrorIs(t, cfg.Err(), ErrMissingVariable)
+	})
+}
+
+func TestWatch(t *testing.T) {
+	levelKey := Variable[string]("LOG_LEVEL")
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "log_level: info\n")
+	f, err := File(path, readYAML)
+	require.NoError(t, err)
+	cfg := New(f)
+	Load(cfg, levelKey, "warn")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reloads := make(chan []Change, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cfg.Watch(ctx, 10*time.Millisecond, func(changes []Change, err error) {
+			assert.NoError(t, err)
+			reloads <- changes
+		})
+	}()
+
+	writeFile(t, path, "log_level: debug\n")
+	select {
+	case changes := <-reloads:
+		assert.Equal(t, []Change{{Key: "LOG_LEVEL", Type: "string", Old: "info", New: "debug"}}, changes)
+	case <-time.After(5 * time.Second):
+		t.Fatal("The change to the file was not noticed")
+	}
+	assert.Equal(t, "debug", cfg.String(levelKey))
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,105 @@
+package configura
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive variables wherever they would be printed.
+const Redacted = "[REDACTED]"
+
+// MarkSensitive marks keys as sensitive, such as passwords and API keys. Their values are replaced with Redacted in
+// every error, in the changes reported by Reload, Watch and ReloadOnSignal, in the defaults of FlagSet and when the
+// configuration is printed with fmt, while getters such as String and Get still return the real values. The messages
+// of the errors underlying a ParseError or Violation are withheld as a whole, though they still unwrap to the error.
+func (c *Config) MarkSensitive(keys ...any) {
+	c.rwLock.Lock()
+	defer c.rwLock.Unlock()
+	for _, key := range keys {
+		c.sensitive[key] = struct{}{}
+		if violations, ok := c.violations[key]; ok {
+			c.violations[key] = c.redactViolations(key, violations)
+		}
+	}
+	for i := range c.parseErrors {
+		c.redact(&c.parseErrors[i])
+	}
+}
+
+// IsSensitive reports whether key was marked as sensitive with MarkSensitive, or described as sensitive with Describe.
+func (c *Config) IsSensitive(key any) bool {
+	c.rwLock.RLock()
+	defer c.rwLock.RUnlock()
+	return c.isSensitive(key)
+}
+
+// isSensitive is the lock-free part of IsSensitive. The caller must hold the lock.
+func (c *Config) isSensitive(key any) bool {
+	if _, ok := c.sensitive[key]; ok {
+		return true
+	}
+	meta, _ := MetadataOf(key)
+	return meta.Sensitive
+}
+
+// redact removes the value of a sensitive variable from err. The message of the underlying error is replaced as a
+// whole, since parsers may quote, escape or report a single element of the value. The caller must hold the lock.
+func (c *Config) redact(err *ParseError) {
+	if err.Value == Redacted || !c.sensitiveNamed(err.Key, err.Type) {
+		return
+	}
+	err.Err = redactedError{err: err.Err}
+	err.Value = Redacted
+}
+
+// sensitiveNamed reports whether the variable named name, of type typ, is sensitive. The caller must hold the lock.
+func (c *Config) sensitiveNamed(name, typ string) bool {
+	for key := range c.sensitive {
+		if keyName(key) == name && typeName(key) == typ {
+			return true
+		}
+	}
+	return describedSensitive(name, typ)
+}
+
+// redactViolations replaces the messages of the errors of violations if key is sensitive, in case a rule mentions its
+// value. The caller must hold the lock.
+func (c *Config) redactViolations(key any, violations []Violation) []Violation {
+	if !c.isSensitive(key) {
+		return violations
+	}
+	for i, v := range violations {
+		if _, ok := v.Err.(redactedError); !ok {
+			violations[i].Err = redactedError{err: v.Err}
+		}
+	}
+	return violations
+}
+
+// redactedError hides the message of an error that may hold the value of a sensitive variable, while it still unwraps
+// to the error.
+type redactedError struct {
+	err error
+}
+
+func (e redactedError) Error() string {
+	return "details withheld for a sensitive variable"
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// Format prints the registered variables with their values in their raw form, ordered by name, with the values of
+// sensitive variables replaced with Redacted, such as {API_KEY=[REDACTED] PORT=8080}. It makes printing a
+// configuration with any verb, such as %v, safe.
+func (c *Config) Format(f fmt.State, _ rune) {
+	settings := c.Dump()
+	entries := make([]string, len(settings))
+	for i, s := range settings {
+		entries[i] = s.Key + "=" + s.Value
+	}
+	fmt.Fprintf(f, "{%s}", strings.Join(entries, " "))
+}
+
+var _ fmt.Formatter = (*Config)(nil)
@@ -0,0 +1,151 @@
+package configura
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSensitive(t *testing.T) {
+	passwordKey := Variable[string]("SENSITIVE_PASSWORD")
+	tokenKey := Variable[int]("SENSITIVE_TOKEN")
+	hostKey := Variable[string]("SENSITIVE_HOST")
+
+	t.Run("Getters", func(t *testing.T) {
+		cfg := New(MapSource{string(passwordKey): "hunter2"})
+		cfg.MarkSensitive(passwordKey)
+		Load(cfg, passwordKey, "")
+		assert.True(t, cfg.IsSensitive(passwordKey))
+		assert.False(t, cfg.IsSensitive(hostKey))
+		assert.Equal(t, "hunter2", cfg.String(passwordKey), "Getters should return the real value")
+		assert.Equal(t, "hunter2", Get(cfg, passwordKey))
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		cfg := New(MapSource{string(passwordKey): "hunter2", string(hostKey): "localhost"})
+		cfg.MarkSensitive(passwordKey)
+		Load(cfg, passwordKey, "")
+		Load(cfg, hostKey, "")
+		for _, verb := range []string{"%v", "%+v", "%s", "%#v"} {
+			s := fmt.Sprintf(verb, cfg)
+			assert.Equal(t, "{SENSITIVE_HOST=localhost SENSITIVE_PASSWORD=[REDACTED]}", s, verb)
+		}
+	})
+
+	t.Run("ParseError", func(t *testing.T) {
+		cfg := New(MapSource{string(tokenKey): "s3cr3t"})
+		cfg.MarkSensitive(tokenKey)
+		err := LoadStrict(cfg, tokenKey, 0)
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "s3cr3t")
+		assert.Contains(t, err.Error(), Redacted)
+
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, Redacted, parseErr.Value)
+		assert.ErrorIs(t, err, strconv.ErrSyntax, "The underlying error should still match")
+
+		require.Error(t, cfg.Err())
+		assert.NotContains(t, cfg.Err().Error(), "s3cr3t")
+	})
+
+	t.Run("PartialValues", func(t *testing.T) {
+		listKey := Variable[[]int]("SENSITIVE_LIST")
+		mapKey := Variable[map[string]string]("SENSITIVE_MAP")
+		quotedKey := Variable[int]("SENSITIVE_QUOTED")
+		Describe(quotedKey, Metadata{Sensitive: true})
+
+		cfg := New(MapSource{
+			string(listKey):   "123,hunter2",
+			string(mapKey):    "user=a,hunter2",
+			string(quotedKey): `hun"ter2`,
+		})
+		cfg.MarkSensitive(listKey, mapKey)
+		require.ErrorIs(t, LoadStrict(cfg, listKey, nil), strconv.ErrSyntax)
+		require.Error(t, LoadStrict(cfg, mapKey, nil))
+		require.ErrorIs(t, LoadStrict(cfg, quotedKey, 0), strconv.ErrSyntax)
+
+		err := cfg.Err()
+		require.Error(t, err)
+		assert.NotContains(t, err.Error(), "hunter2")
+		assert.NotContains(t, err.Error(), "ter2")
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+
+	t.Run("MarkedAfterLoad", func(t *testing.T) {
+		cfg := New(MapSource{string(tokenKey): "s3cr3t"})
+		require.Error(t, LoadStrict(cfg, tokenKey, 0))
+		require.Contains(t, cfg.Err().Error(), "s3cr3t")
+
+		cfg.MarkSensitive(tokenKey)
+		assert.NotContains(t, cfg.Err().Error(), "s3cr3t")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		cfg := New(MapSource{string(passwordKey): "short"})
+		Load(cfg, passwordKey, "")
+		Validate(cfg, passwordKey, func(value string) error {
+			return fmt.Errorf("%q is too short", value)
+		})
+		require.Contains(t, cfg.Err().Error(), "short\" is too short")
+
+		cfg.MarkSensitive(passwordKey)
+		err := cfg.Err()
+		require.ErrorIs(t, err, ErrValidation)
+		assert.NotContains(t, err.Error(), `"short"`)
+
+		err = Write(cfg, map[Variable[string]]string{passwordKey: "tiny"})
+		require.ErrorIs(t, err, ErrValidation)
+		assert.NotContains(t, err.Error(), "tiny")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeFile(t, path, "sensitive_password: old\nsensitive_host: a\n")
+		f, err := File(path, readYAML)
+		require.NoError(t, err)
+		cfg := New(f)
+		cfg.MarkSensitive(passwordKey)
+		Load(cfg, passwordKey, "")
+		Load(cfg, hostKey, "")
+
+		var updates []string
+		defer OnChange(cfg, passwordKey, func(_, new string) { updates = append(updates, new) })()
+
+		writeFile(t, path, "sensitive_password: new\nsensitive_host: b\n")
+		changes, err := cfg.Reload()
+		require.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Key: "SENSITIVE_HOST", Type: "string", Old: "a", New: "b"},
+			{Key: "SENSITIVE_PASSWORD", Type: "string", Old: Redacted, New: Redacted},
+		}, changes)
+		assert.Equal(t, []string{"new"}, updates, "Subscribers should receive the real value")
+	})
+
+	t.Run("FlagSet", func(t *testing.T) {
+		cfg := New()
+		cfg.MarkSensitive(passwordKey)
+		Load(cfg, passwordKey, "fallback-secret")
+		Load(cfg, hostKey, "localhost")
+		fs := cfg.FlagSet("app", flag.ContinueOnError)
+		assert.Equal(t, "", fs.Lookup(FlagName(string(passwordKey))).DefValue)
+		assert.Equal(t, "localhost", fs.Lookup(FlagName(string(hostKey))).DefValue)
+
+		require.NoError(t, fs.Parse([]string{"-sensitive-password", "from-flag"}))
+		assert.Equal(t, "from-flag", cfg.String(passwordKey))
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		cfg := New(MapSource{string(passwordKey): "hunter2"})
+		cfg.MarkSensitive(passwordKey)
+		Load(cfg, passwordKey, "")
+		merged := Merge(cfg)
+		assert.True(t, merged.IsSensitive(passwordKey))
+		assert.NotContains(t, fmt.Sprint(merged), "hunter2")
+	})
+}
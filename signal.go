@@ -0,0 +1,37 @@
+package configura
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads the configuration whenever the process receives one of signals, or SIGHUP if none are given,
+// until ctx is done. Every loaded variable is resolved again against its sources, after the files of every FileSource
+// among the layers were read again if they were modified. The result is validated first: unless every required
+// variable is set and every value parses, even for variables loaded with Load, the configuration is left as it was.
+// onReload, if not nil, is called with the variables whose value changed after every reload that changed any, and with
+// the error of every reload that failed. The signals are handled from the moment ReloadOnSignal returns.
+func (c *Config) ReloadOnSignal(ctx context.Context, onReload func(changes []Change, err error), signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	go func() {
+		defer signal.Stop(received)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-received:
+				changes, err := c.reload(true, true)
+				if onReload != nil && (err != nil || len(changes) > 0) {
+					onReload(changes, err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+//go:build unix
+
+package configura
+
+import (
+	"context"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadOnSignal(t *testing.T) {
+	levelKey := Variable[string]("SIGNAL_LEVEL")
+	limitKey := Variable[int]("SIGNAL_LIMIT")
+	tokenKey := Variable[string]("SIGNAL_TOKEN")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeFile(t, path, "signal_level: info\nsignal_limit: 100\nsignal_token: secret\n")
+	f, err := File(path, readYAML)
+	require.NoError(t, err)
+	cfg := New(f, Env)
+	Load(cfg, levelKey, "warn")
+	Load(cfg, limitKey, 10)
+	require.NoError(t, LoadRequired(cfg, tokenKey))
+
+	type reload struct {
+		changes []Change
+		err     error
+	}
+	reloads := make(chan reload, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg.ReloadOnSignal(ctx, func(changes []Change, err error) {
+		reloads <- reload{changes: changes, err: err}
+	}, syscall.SIGUSR1)
+
+	signal := func(t *testing.T) reload {
+		t.Helper()
+		require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+		select {
+		case r := <-reloads:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("The signal did not reload the configuration")
+			return reload{}
+		}
+	}
+
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv(string(limitKey), "200")
+		r := signal(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, []Change{{Key: "SIGNAL_LIMIT", Type: "int", Old: 100, New: 200}}, r.changes)
+		assert.Equal(t, 200, cfg.Int(limitKey))
+	})
+
+	t.Run("File", func(t *testing.T) {
+		writeFile(t, path, "signal_level: debug\nsignal_limit: 100\nsignal_token: secret\n")
+		r := signal(t)
+		require.NoError(t, r.err)
+		assert.Equal(t, []Change{
+			{Key: "SIGNAL_LEVEL", Type: "string", Old: "info", New: "debug"},
+			{Key: "SIGNAL_LIMIT", Type: "int", Old: 200, New: 100},
+		}, r.changes, "The limit should be read from the file once it is no longer set in the environment")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		writeFile(t, path, "signal_level: error\nsignal_limit: many\nsignal_token: secret\n")
+		r := signal(t)
+		assert.ErrorIs(t, r.err, ErrInvalidVariable)
+		assert.Equal(t, "debug", cfg.String(levelKey), "A failed validation should leave the configuration as it was")
+		assert.Equal(t, 100, cfg.Int(limitKey))
+		assert.NoError(t, cfg.Err())
+	})
+
+	t.Run("MissingRequired", func(t *testing.T) {
+		writeFile(t, path, "signal_level: error\nsignal_limit: 50\n")
+		r := signal(t)
+		assert.ErrorIs(t, r.err, ErrMissingVariable)
+		assert.Equal(t, "secret", cfg.String(tokenKey))
+		assert.Equal(t, 100, cfg.Int(limitKey))
+		origin, _ := cfg.Origin(tokenKey)
+		assert.Equal(t, path, origin.Source)
+
+		writeFile(t, path, "signal_level: error\nsignal_limit: 50\nsignal_token: other\n")
+		r = signal(t)
+		require.NoError(t, r.err)
+		assert.Len(t, r.changes, 3, "The file should be read again after a failed validation")
+	})
+}
@@ -0,0 +1,67 @@
+package configura
+
+import "os"
+
+// Source provides the raw, unparsed values that Load converts into typed configuration variables. Lookup returns the
+// value stored under key, and whether the source holds a value for it at all.
+type Source interface {
+	Lookup(key string) (string, bool)
+}
+
+// SourceFunc adapts an ordinary function to the Source interface.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// MapSource is a Source backed by a map of raw values, which is useful for static defaults and tests.
+type MapSource map[string]string
+
+// Lookup returns the value stored under key in the map.
+func (m MapSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// environment is the Source that reads the environment of the current process.
+type environment struct{}
+
+// Lookup returns the environment variable named by key.
+func (environment) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Name returns EnvLayer.
+func (environment) Name() string {
+	return EnvLayer
+}
+
+// EnvLayer is the name of the layer that reads the environment through Env.
+const EnvLayer = "env"
+
+// Env is the Source that reads environment variables. It is the only source of a Config created without any.
+var Env Source = environment{}
+
+// ValueSource is implemented by sources that hold structured values rather than plain strings, such as decoded
+// configuration files. Load converts such values directly into the type of a variable, instead of parsing the string
+// form returned by Lookup.
+type ValueSource interface {
+	Source
+	LookupValue(key string) (Value, bool)
+}
+
+// Value is a structured value held by a ValueSource. Data is a string, bool, number, []any or map[string]any, where a
+// number is a json.Number or any Go integer or floating point type. Location tells where the value was found, for use
+// in error messages, e.g. the path of a JSON field.
+type Value struct {
+	Data     any
+	Location string
+}
+
+var (
+	_ Source = SourceFunc(nil)
+	_ Source = MapSource(nil)
+	_ Source = environment{}
+)
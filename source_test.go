@@ -0,0 +1,100 @@
+package configura
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSources(t *testing.T) {
+	t.Run("MapSource", func(t *testing.T) {
+		src := MapSource{"KEY": "value"}
+		raw, ok := src.Lookup("KEY")
+		assert.True(t, ok)
+		assert.Equal(t, "value", raw)
+		_, ok = src.Lookup("OTHER")
+		assert.False(t, ok)
+	})
+
+	t.Run("SourceFunc", func(t *testing.T) {
+		src := SourceFunc(func(key string) (string, bool) { return key + "!", true })
+		raw, ok := src.Lookup("KEY")
+		assert.True(t, ok)
+		assert.Equal(t, "KEY!", raw)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv("SOURCE_ENV_KEY", "from-env")
+		raw, ok := Env.Lookup("SOURCE_ENV_KEY")
+		assert.True(t, ok)
+		assert.Equal(t, "from-env", raw)
+		_, ok = Env.Lookup("SOURCE_ENV_KEY_UNSET")
+		assert.False(t, ok)
+	})
+
+	t.Run("LaterSourcesTakePrecedence", func(t *testing.T) {
+		cfg := New(MapSource{"A": "first", "B": "first"}, MapSource{"A": "second"})
+		Load(cfg, Variable[string]("A"), "fallback")
+		Load(cfg, Variable[string]("B"), "fallback")
+		Load(cfg, Variable[string]("C"), "fallback")
+		assert.Equal(t, "second", cfg.String("A"))
+		assert.Equal(t, "first", cfg.String("B"))
+		assert.Equal(t, "fallback", cfg.String("C"))
+	})
+}
+
+func TestLoadFromSources(t *testing.T) {
+	portKey := Variable[int]("SOURCE_PORT")
+	hostKey := Variable[string]("SOURCE_HOST")
+
+	t.Run("DefaultsToEnvironment", func(t *testing.T) {
+		t.Setenv(string(portKey), "8080")
+		cfg := New()
+		Load(cfg, portKey, 3000)
+		assert.Equal(t, 8080, cfg.Int(portKey))
+	})
+
+	t.Run("ConfigSources", func(t *testing.T) {
+		t.Setenv(string(portKey), "8080")
+		cfg := New(MapSource{string(portKey): "9090", string(hostKey): "file-host"}, Env)
+		Load(cfg, portKey, 3000)
+		Load(cfg, hostKey, "localhost")
+		assert.Equal(t, 8080, cfg.Int(portKey), "The environment should override the map source")
+		assert.Equal(t, "file-host", cfg.String(hostKey))
+	})
+
+	t.Run("SourcesDoNotFallBackToEnvironment", func(t *testing.T) {
+		t.Setenv(string(portKey), "8080")
+		cfg := New(MapSource{})
+		Load(cfg, portKey, 3000)
+		assert.Equal(t, 3000, cfg.Int(portKey))
+	})
+
+	t.Run("PerCallSources", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "9090"})
+		Load(cfg, portKey, 3000, MapSource{string(portKey): "7070"})
+		assert.Equal(t, 7070, cfg.Int(portKey))
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "80a0"})
+		err := LoadStrict(cfg, portKey, 3000)
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "80a0", parseErr.Value)
+	})
+
+	t.Run("Required", func(t *testing.T) {
+		require.NoError(t, os.Unsetenv(string(hostKey)))
+		cfg := New(MapSource{string(hostKey): "file-host"})
+		require.NoError(t, LoadRequired(cfg, hostKey))
+		assert.Equal(t, "file-host", cfg.String(hostKey))
+
+		cfg = New()
+		assert.ErrorIs(t, LoadRequired(cfg, hostKey), ErrMissingVariable)
+		assert.NoError(t, LoadRequired(cfg, hostKey, MapSource{string(hostKey): "call-host"}))
+		assert.Equal(t, "call-host", cfg.String(hostKey))
+	})
+}
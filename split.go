@@ -0,0 +1,188 @@
+package configura
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultListSeparator is the separator used to split list values when no other separator has been configured.
+	DefaultListSeparator = ","
+	// DefaultEntrySeparator is the separator between the key/value pairs of a map value.
+	DefaultEntrySeparator = ","
+	// DefaultPairSeparator is the separator between a key and its value within a map entry.
+	DefaultPairSeparator = "="
+)
+
+// splitList splits raw on sep and trims surrounding whitespace from every element. An element may be wrapped in single
+// or double quotes to keep separators and whitespace intact, and a backslash escapes the character that follows it
+// (except inside single quotes). A blank input yields an empty, non-nil slice, and an empty sep is an error.
+func splitList(raw, sep string) ([]string, error) {
+	if sep == "" {
+		return nil, errors.New("list separator must not be empty")
+	}
+	if strings.TrimSpace(raw) == "" {
+		return []string{}, nil
+	}
+
+	var (
+		items []string
+		item  strings.Builder
+		quote rune
+		// keep is the length of item that trimming must not touch, as it was quoted or escaped.
+		keep    int
+		started bool
+	)
+
+	flush := func() {
+		s := item.String()
+		items = append(items, s[:keep]+strings.TrimRightFunc(s[keep:], unicode.IsSpace))
+		item.Reset()
+		keep = 0
+		started = false
+	}
+
+	for i := 0; i < len(raw); {
+		r, size := utf8.DecodeRuneInString(raw[i:])
+		switch {
+		case quote != 0:
+			switch {
+			case r == quote:
+				quote = 0
+				keep = item.Len()
+			case r == '\\' && quote == '"' && i+size < len(raw):
+				next, nextSize := utf8.DecodeRuneInString(raw[i+size:])
+				item.WriteRune(next)
+				size += nextSize
+			default:
+				item.WriteRune(r)
+			}
+		case r == '\\' && i+size < len(raw):
+			next, nextSize := utf8.DecodeRuneInString(raw[i+size:])
+			item.WriteRune(next)
+			keep = item.Len()
+			started = true
+			size += nextSize
+		case (r == '"' || r == '\'') && !started:
+			quote = r
+			started = true
+		case strings.HasPrefix(raw[i:], sep):
+			flush()
+			size = len(sep)
+		case unicode.IsSpace(r) && !started:
+		default:
+			item.WriteRune(r)
+			started = true
+		}
+		i += size
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	flush()
+
+	return items, nil
+}
+
+// parseList splits raw on sep and converts every element with parse. It fails on the first element that cannot be
+// converted.
+func parseList[T any](raw, sep string, parse func(string) (T, error)) ([]T, error) {
+	items, err := splitList(raw, sep)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(items))
+	for i, item := range items {
+		if values[i], err = parse(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// checkMapSeparators reports an error unless both separators are non-empty and differ from each other.
+func checkMapSeparators(entrySep, pairSep string) error {
+	switch {
+	case entrySep == "":
+		return errors.New("entry separator must not be empty")
+	case pairSep == "":
+		return errors.New("pair separator must not be empty")
+	case entrySep == pairSep:
+		return fmt.Errorf("entry and pair separators must differ, both are %q", entrySep)
+	}
+	return nil
+}
+
+// parseMap splits raw into entries on entrySep, following the same quoting and escaping rules as splitList, and every
+// entry into a key and a value on the first occurrence of pairSep. Keys and values are trimmed, values are converted
+// with parse, blank entries are skipped and a repeated key keeps its last value. Both separators must be non-empty and
+// differ from each other.
+func parseMap[T any](raw, entrySep, pairSep string, parse func(string) (T, error)) (map[string]T, error) {
+	if err := checkMapSeparators(entrySep, pairSep); err != nil {
+		return nil, err
+	}
+
+	entries, err := splitList(raw, entrySep)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]T, len(entries))
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(entry, pairSep)
+		if !found {
+			return nil, fmt.Errorf("entry %q is missing separator %q", entry, pairSep)
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("entry %q has an empty key", entry)
+		}
+
+		if values[key], err = parse(strings.TrimSpace(value)); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// joinList joins items with sep, such that splitList splits the result back into the same items. Items that contain
+// sep, quotes, backslashes or surrounding whitespace are wrapped in double quotes.
+func joinList(items []string, sep string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		if needsQuotes(item, sep) || item == "" && len(items) == 1 {
+			item = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(item) + `"`
+		}
+		quoted[i] = item
+	}
+	return strings.Join(quoted, sep)
+}
+
+// joinMap joins the entries of values with entrySep, and every key and value with pairSep, such that parseMap parses
+// the result back into the same map. Entries are sorted by key.
+func joinMap(values map[string]string, entrySep, pairSep string) string {
+	entries := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		entries = append(entries, key+pairSep+values[key])
+	}
+	return joinList(entries, entrySep)
+}
+
+// needsQuotes reports whether item must be quoted to survive splitList.
+func needsQuotes(item, sep string) bool {
+	return strings.Contains(item, sep) || strings.ContainsAny(item, `"'\`) || strings.TrimSpace(item) != item
+}
@@ -0,0 +1,167 @@
+package configura
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		raw      string
+		sep      string
+		expected []string
+	}{
+		{"Empty", "", ",", []string{}},
+		{"Blank", "   ", ",", []string{}},
+		{"Single", "a", ",", []string{"a"}},
+		{"Multiple", "a,b,c", ",", []string{"a", "b", "c"}},
+		{"TrimsWhitespace", " a , b ,c ", ",", []string{"a", "b", "c"}},
+		{"KeepsInnerWhitespace", "hello world, foo bar", ",", []string{"hello world", "foo bar"}},
+		{"EmptyElements", "a,,b,", ",", []string{"a", "", "b", ""}},
+		{"CustomSeparator", "a;b; c", ";", []string{"a", "b", "c"}},
+		{"MultiCharSeparator", "a::b::c", "::", []string{"a", "b", "c"}},
+		{"DoubleQuoted", `"a,b", c`, ",", []string{"a,b", "c"}},
+		{"SingleQuoted", `'a,b', c`, ",", []string{"a,b", "c"}},
+		{"QuotedWhitespace", `" a ",b`, ",", []string{" a ", "b"}},
+		{"EscapedSeparator", `a\,b,c`, ",", []string{"a,b", "c"}},
+		{"EscapedQuoteInDoubleQuotes", `"say \"hi\"",x`, ",", []string{`say "hi"`, "x"}},
+		{"BackslashInSingleQuotes", `'a\b',c`, ",", []string{`a\b`, "c"}},
+		{"QuoteInsideElement", "it's,fine", ",", []string{"it's", "fine"}},
+		{"TrailingBackslash", `a\`, ",", []string{`a\`}},
+		{"Unicode", "å,ä, ö", ",", []string{"å", "ä", "ö"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, err := splitList(tc.raw, tc.sep)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, items)
+		})
+	}
+
+	t.Run("UnterminatedQuote", func(t *testing.T) {
+		_, err := splitList(`"a,b`, ",")
+		assert.Error(t, err)
+	})
+
+	t.Run("EmptySeparator", func(t *testing.T) {
+		_, err := splitList("a,b", "")
+		assert.ErrorContains(t, err, "separator must not be empty")
+		_, err = splitList("", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseList(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		values, err := parseList("1, 2,3", ",", func(s string) (int, error) {
+			return len(s), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 1, 1}, values)
+	})
+	t.Run("InvalidElement", func(t *testing.T) {
+		_, err := parseList("1,x", ",", func(s string) (int, error) {
+			if s == "x" {
+				return 0, assert.AnError
+			}
+			return 1, nil
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestParseMap(t *testing.T) {
+	identity := func(s string) (string, error) { return s, nil }
+
+	testCases := []struct {
+		name     string
+		raw      string
+		entrySep string
+		pairSep  string
+		expected map[string]string
+	}{
+		{"Empty", "", ",", "=", map[string]string{}},
+		{"Single", "a=1", ",", "=", map[string]string{"a": "1"}},
+		{"Multiple", "a=1, b = 2 ,c=3", ",", "=", map[string]string{"a": "1", "b": "2", "c": "3"}},
+		{"ValueContainsPairSeparator", "token=a=b", ",", "=", map[string]string{"token": "a=b"}},
+		{"QuotedEntry", `"X-Tags=a,b",y=2`, ",", "=", map[string]string{"X-Tags": "a,b", "y": "2"}},
+		{"EmptyValue", "a=", ",", "=", map[string]string{"a": ""}},
+		{"SkipsBlankEntries", "a=1,,b=2,", ",", "=", map[string]string{"a": "1", "b": "2"}},
+		{"DuplicateKeyLastWins", "a=1,a=2", ",", "=", map[string]string{"a": "2"}},
+		{"CustomSeparators", "a:1;b:2", ";", ":", map[string]string{"a": "1", "b": "2"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, err := parseMap(tc.raw, tc.entrySep, tc.pairSep, identity)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, values)
+		})
+	}
+
+	t.Run("MissingPairSeparator", func(t *testing.T) {
+		_, err := parseMap("a=1,b", ",", "=", identity)
+		assert.ErrorContains(t, err, `"b"`)
+	})
+	t.Run("EmptyKey", func(t *testing.T) {
+		_, err := parseMap("=1", ",", "=", identity)
+		assert.ErrorContains(t, err, "empty key")
+	})
+	t.Run("InvalidValue", func(t *testing.T) {
+		_, err := parseMap("a=1", ",", "=", func(string) (int, error) { return 0, assert.AnError })
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("EmptyEntrySeparator", func(t *testing.T) {
+		_, err := parseMap("a=1,b=2", "", "=", identity)
+		assert.ErrorContains(t, err, "entry separator must not be empty")
+	})
+	t.Run("EmptyPairSeparator", func(t *testing.T) {
+		_, err := parseMap("a=1,b=2", ",", "", identity)
+		assert.ErrorContains(t, err, "pair separator must not be empty")
+	})
+	t.Run("EqualSeparators", func(t *testing.T) {
+		_, err := parseMap("a=1=b=2", "=", "=", identity)
+		assert.ErrorContains(t, err, "must differ")
+	})
+}
+
+func TestJoinList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		items    []string
+		sep      string
+		expected string
+	}{
+		{"Empty", []string{}, ",", ""},
+		{"Plain", []string{"a", "b"}, ",", "a,b"},
+		{"ContainsSeparator", []string{"a,b", "c"}, ",", `"a,b",c`},
+		{"Whitespace", []string{" a", "b c"}, ",", `" a",b c`},
+		{"Quotes", []string{`say "hi"`, `it's`, `a\b`}, ";", `"say \"hi\"";"it's";"a\\b"`},
+		{"SingleEmpty", []string{""}, ",", `""`},
+		{"EmptyElements", []string{"a", ""}, ",", "a,"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := joinList(tc.items, tc.sep)
+			assert.Equal(t, tc.expected, raw)
+
+			items, err := splitList(raw, tc.sep)
+			require.NoError(t, err)
+			assert.Equal(t, tc.items, items, "splitList should reverse joinList")
+		})
+	}
+}
+
+func TestJoinMap(t *testing.T) {
+	raw := joinMap(map[string]string{"b": "2", "a": "x,y"}, ",", "=")
+	assert.Equal(t, `"a=x,y",b=2`, raw)
+
+	values, err := parseMap(raw, ",", "=", parseString)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "x,y", "b": "2"}, values)
+}
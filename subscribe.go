@@ -0,0 +1,205 @@
+package configura
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Update is the change of the value of a Variable[T], as sent by Subscribe. A variable that is not registered has the
+// zero value of T.
+type Update[T any] struct {
+	Old T
+	New T
+}
+
+// subscription is a callback registered for a single variable.
+type subscription struct {
+	notify    func(old, new any)
+	cancelled atomic.Bool
+}
+
+// notification is a change of a variable that is waiting to be delivered to its subscribers.
+type notification struct {
+	key           any
+	subscriptions []*subscription
+	old, new      any
+}
+
+// OnChange calls fn after every change of the value of key, whether it was written with Write, loaded, or resolved
+// again because a layer changed. Only changes are notified: writing, loading or resolving a value that is equal to the
+// current one, as compared with reflect.DeepEqual, does not call fn. Callbacks are never called while the
+// configuration is locked, so they may read and write it. Across all subscriptions of a configuration, callbacks are
+// called one at a time and in the order of the changes. A callback that changes the configuration itself sees the
+// resulting callbacks after it returns. Calling the returned function cancels the subscription.
+func OnChange[T any](cfg *Config, key Variable[T], fn func(old, new T)) (cancel func()) {
+	sub := &subscription{notify: func(old, new any) {
+		o, _ := old.(T)
+		n, _ := new.(T)
+		fn(o, n)
+	}}
+
+	cfg.rwLock.Lock()
+	defer cfg.rwLock.Unlock()
+	cfg.subscriptions[key] = append(cfg.subscriptions[key], sub)
+
+	return func() {
+		if sub.cancelled.Swap(true) {
+			return
+		}
+		cfg.rwLock.Lock()
+		defer cfg.rwLock.Unlock()
+		cfg.subscriptions[key] = slices.DeleteFunc(cfg.subscriptions[key], func(s *subscription) bool { return s == sub })
+		if len(cfg.subscriptions[key]) == 0 {
+			delete(cfg.subscriptions, key)
+		}
+	}
+}
+
+// Subscribe works like OnChange, but sends every change of the value of key to the returned channel, which holds up
+// to buffer changes. Changes are sent from a goroutine of the subscription, so a channel that is not drained never
+// blocks the writers of the configuration or its other subscribers. While the channel is full, up to buffer further
+// changes wait to be sent, and any change beyond those is coalesced into the last of them, which then goes from its
+// Old value to the latest New value. Calling the returned function cancels the subscription, stops its goroutine and
+// closes the channel, so it must be called once the channel is no longer read.
+func Subscribe[T any](cfg *Config, key Variable[T], buffer int) (updates <-chan Update[T], cancel func()) {
+	ch := make(chan Update[T], buffer)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	wake := make(chan struct{}, 1)
+	var mu sync.Mutex
+	var queue []Update[T]
+
+	stop := OnChange(cfg, key, func(old, new T) {
+		mu.Lock()
+		if last := len(queue) - 1; last >= max(buffer, 1)-1 {
+			queue[last].New = new
+			if reflect.DeepEqual(queue[last].Old, new) {
+				queue = queue[:last]
+			}
+		} else {
+			queue = append(queue, Update[T]{Old: old, New: new})
+		}
+		mu.Unlock()
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	})
+
+	go func() {
+		defer close(stopped)
+		defer close(ch)
+		for {
+			select {
+			case <-done:
+				return
+			case <-wake:
+			}
+			for {
+				mu.Lock()
+				if len(queue) == 0 {
+					mu.Unlock()
+					break
+				}
+				u := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+				select {
+				case <-done:
+					return
+				case ch <- u:
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			stop()
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// lock takes the write lock ahead of changing the values of the configuration, and returns the values of the
+// variables that have subscribers, to be passed to unlock.
+func (c *Config) lock() map[any]any {
+	c.rwLock.Lock()
+	if len(c.subscriptions) == 0 {
+		return nil
+	}
+	before := make(map[any]any, len(c.subscriptions))
+	for key := range c.subscriptions {
+		if value, ok := c.value(key); ok {
+			before[key] = value
+		}
+	}
+	return before
+}
+
+// unlock publishes the changes made since lock, releases the write lock, and notifies the subscribers of every
+// variable whose value changed.
+func (c *Config) unlock(before map[any]any) {
+	var pending []notification
+	for key, subs := range c.subscriptions {
+		old := before[key]
+		value, ok := c.value(key)
+		if !ok {
+			value = nil
+		}
+		if !reflect.DeepEqual(old, value) {
+			pending = append(pending, notification{key: key, subscriptions: slices.Clone(subs), old: old, new: value})
+		}
+	}
+	slices.SortFunc(pending, func(a, b notification) int {
+		return cmp.Or(cmp.Compare(keyName(a.key), keyName(b.key)), cmp.Compare(typeName(a.key), typeName(b.key)))
+	})
+
+	c.publish()
+	c.notifyLock.Lock()
+	c.notifications = append(c.notifications, pending...)
+	c.rwLock.Unlock()
+	if c.notifying || len(c.notifications) == 0 {
+		c.notifyLock.Unlock()
+		return
+	}
+	c.notifying = true
+	c.notifyLock.Unlock()
+
+	c.notify()
+}
+
+// notify delivers the pending notifications in order, until there are none left. If a callback panics, the
+// notifications that are still pending are delivered by the next change.
+func (c *Config) notify() {
+	defer func() {
+		if r := recover(); r != nil {
+			c.notifyLock.Lock()
+			c.notifying = false
+			c.notifyLock.Unlock()
+			panic(r)
+		}
+	}()
+
+	for {
+		c.notifyLock.Lock()
+		if len(c.notifications) == 0 {
+			c.notifying = false
+			c.notifyLock.Unlock()
+			return
+		}
+		n := c.notifications[0]
+		c.notifications = c.notifications[1:]
+		c.notifyLock.Unlock()
+
+		for _, sub := range n.subscriptions {
+			if !sub.cancelled.Load() {
+				sub.notify(n.old, n.new)
+			}
+		}
+	}
+}
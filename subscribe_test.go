@@ -0,0 +1,179 @@
+package configura
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnChange(t *testing.T) {
+	portKey := Variable[int]("SUB_PORT")
+	levelKey := Variable[string]("SUB_LEVEL")
+
+	t.Run("Write", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, portKey, 3000)
+
+		var updates []Update[int]
+		cancel := OnChange(cfg, portKey, func(old, new int) {
+			updates = append(updates, Update[int]{Old: old, New: new})
+		})
+		Write(cfg, map[Variable[int]]int{portKey: 8080})
+		Write(cfg, map[Variable[int]]int{portKey: 8080})
+		Write(cfg, map[Variable[int]]int{portKey: 9090})
+		assert.Equal(t, []Update[int]{{Old: 3000, New: 8080}, {Old: 8080, New: 9090}}, updates, "Writing the same value should not notify")
+
+		cancel()
+		cancel()
+		Write(cfg, map[Variable[int]]int{portKey: 1})
+		assert.Len(t, updates, 2, "Cancelled subscriptions should not be notified")
+	})
+
+	t.Run("SameValue", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "8080"})
+		Load(cfg, portKey, 3000)
+
+		notified := 0
+		defer OnChange(cfg, portKey, func(_, _ int) { notified++ })()
+		require.NoError(t, Write(cfg, map[Variable[int]]int{portKey: 8080}))
+		require.NoError(t, cfg.Refresh())
+		cfg.Reset(portKey)
+		cfg.AddLayer("same", MapSource{string(portKey): "8080"})
+		assert.Zero(t, notified, "Writing, loading or resolving the current value should not notify")
+	})
+
+	t.Run("LoadAndLayers", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "8080"})
+		var updates []Update[int]
+		OnChange(cfg, portKey, func(old, new int) {
+			updates = append(updates, Update[int]{Old: old, New: new})
+		})
+
+		Load(cfg, portKey, 3000)
+		cfg.AddLayer("override", MapSource{string(portKey): "9090"})
+		cfg.RemoveLayer("override")
+		cfg.Reset(portKey)
+		assert.Equal(t, []Update[int]{{Old: 0, New: 8080}, {Old: 8080, New: 9090}, {Old: 9090, New: 8080}}, updates)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		writeFile(t, path, "sub_level: info\n")
+		f, err := File(path, readYAML)
+		require.NoError(t, err)
+		cfg := New(f)
+		Load(cfg, levelKey, "warn")
+
+		var updates []Update[string]
+		OnChange(cfg, levelKey, func(old, new string) {
+			updates = append(updates, Update[string]{Old: old, New: new})
+		})
+		writeFile(t, path, "sub_level: debug\n")
+		_, err = cfg.Reload()
+		require.NoError(t, err)
+		assert.Equal(t, []Update[string]{{Old: "info", New: "debug"}}, updates)
+	})
+
+	t.Run("CallbacksMayUseTheConfiguration", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, portKey, 3000)
+		Load(cfg, levelKey, "info")
+
+		var order []string
+		OnChange(cfg, portKey, func(old, new int) {
+			order = append(order, "port")
+			assert.Equal(t, new, cfg.Int(portKey))
+			Write(cfg, map[Variable[string]]string{levelKey: "debug"})
+			order = append(order, "port done")
+		})
+		OnChange(cfg, levelKey, func(old, new string) {
+			order = append(order, "level "+new)
+		})
+
+		Write(cfg, map[Variable[int]]int{portKey: 8080})
+		assert.Equal(t, []string{"port", "port done", "level debug"}, order, "Changes made by a callback should be notified after it returns")
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, portKey, 0)
+
+		var mu sync.Mutex
+		var seen []int
+		OnChange(cfg, portKey, func(old, new int) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen = append(seen, new)
+		})
+
+		var wg sync.WaitGroup
+		for i := 1; i <= 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				Write(cfg, map[Variable[int]]int{portKey: i})
+			}()
+		}
+		wg.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.NotEmpty(t, seen)
+		assert.Equal(t, cfg.Int(portKey), seen[len(seen)-1], "The last notification should carry the final value")
+	})
+}
+
+func TestSubscribe(t *testing.T) {
+	portKey := Variable[int]("SUB_PORT")
+	cfg := New(MapSource{})
+	Load(cfg, portKey, 3000)
+
+	updates, cancel := Subscribe(cfg, portKey, 2)
+	Write(cfg, map[Variable[int]]int{portKey: 8080})
+	Write(cfg, map[Variable[int]]int{portKey: 9090})
+	assert.Equal(t, Update[int]{Old: 3000, New: 8080}, <-updates)
+	assert.Equal(t, Update[int]{Old: 8080, New: 9090}, <-updates)
+
+	cancel()
+	Write(cfg, map[Variable[int]]int{portKey: 1})
+	_, ok := <-updates
+	assert.False(t, ok, "The channel should be closed once the subscription is cancelled")
+	cancel()
+}
+
+func TestSubscribeSlowConsumer(t *testing.T) {
+	portKey := Variable[int]("SUB_SLOW_PORT")
+	cfg := New(MapSource{})
+	Load(cfg, portKey, 0)
+
+	updates, cancel := Subscribe(cfg, portKey, 1)
+	defer cancel()
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for i := 1; i <= 100; i++ {
+			Write(cfg, map[Variable[int]]int{portKey: i})
+		}
+	}()
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("A full channel should not block writers")
+	}
+
+	var last Update[int]
+	for last.New != 100 {
+		select {
+		case u := <-updates:
+			assert.Equal(t, last.New, u.Old, "Updates should be delivered in order")
+			last = u
+		case <-time.After(5 * time.Second):
+			t.Fatal("The latest change should be delivered")
+		}
+	}
+}
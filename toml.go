@@ -0,0 +1,85 @@
+package configura
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// TOMLFile reads the TOML document at path with ParseTOML. The locations of its values are prefixed with path.
+func TOMLFile(path string, mapper KeyMapper) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	root, err := decodeTOML(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return newDocument(root, mapper, tomlKey, path)
+}
+
+// ParseTOML parses a TOML document into a Document. Tables are flattened into variable names with mapper, which
+// defaults to UpperSnakeCase when nil. Arrays, including arrays of tables, can be loaded into list variables, and
+// datetimes are kept in their TOML form, such as 2024-01-02T03:04:05Z or 2024-01-02 for a local date, so that they can
+// be loaded into string variables. The location of each value is its dotted key, such as database.url.
+func ParseTOML(r io.Reader, mapper KeyMapper) (*Document, error) {
+	root, err := decodeTOML(r)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(root, mapper, tomlKey, "")
+}
+
+// decodeTOML decodes a TOML document from r.
+func decodeTOML(r io.Reader) (map[string]any, error) {
+	var root map[string]any
+	if _, err := toml.NewDecoder(r).Decode(&root); err != nil {
+		return nil, err
+	}
+	return tomlValue(root).(map[string]any), nil
+}
+
+// tomlValue converts the datetimes within a decoded TOML value to strings, and arrays of tables to []any.
+func tomlValue(data any) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for k, item := range v {
+			v[k] = tomlValue(item)
+		}
+		return v
+	case []map[string]any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = tomlValue(item)
+		}
+		return items
+	case []any:
+		for i, item := range v {
+			v[i] = tomlValue(item)
+		}
+		return v
+	case time.Time:
+		switch v.Location().String() {
+		case "datetime-local":
+			return v.Format("2006-01-02T15:04:05.999999999")
+		case "date-local":
+			return v.Format(time.DateOnly)
+		case "time-local":
+			return v.Format("15:04:05.999999999")
+		}
+		return v.Format(time.RFC3339Nano)
+	}
+	return data
+}
+
+// tomlKey formats the path of a TOML value as a dotted key.
+func tomlKey(path []string) string {
+	return strings.Join(path, ".")
+}
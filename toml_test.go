@@ -0,0 +1,122 @@
+package configura
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTOML = `
+port = 8080
+debug = true
+allowed_origins = ["https://a.example.com", "https://b.example.com"]
+retries = [1, 2, 3]
+ratio = 0.25
+released = 2024-01-02T03:04:05Z
+maintenance_day = 2024-06-01
+backup_at = 02:30:00
+
+[database]
+url = "postgres://localhost/db"
+max-connections = 10
+timeout = "5s"
+
+[rate_limits]
+acme = 100
+globex = 250
+
+[[servers]]
+name = "a"
+`
+
+func TestParseTOML(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		doc, err := ParseTOML(strings.NewReader(testTOML), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		Load(cfg, Variable[int]("PORT"), 3000)
+		Load(cfg, Variable[bool]("DEBUG"), false)
+		Load(cfg, Variable[[]string]("ALLOWED_ORIGINS"), nil)
+		Load(cfg, Variable[[]int]("RETRIES"), nil)
+		Load(cfg, Variable[float64]("RATIO"), 1)
+		Load(cfg, Variable[string]("RELEASED"), "")
+		Load(cfg, Variable[string]("MAINTENANCE_DAY"), "")
+		Load(cfg, Variable[string]("BACKUP_AT"), "")
+		Load(cfg, Variable[string]("DATABASE_URL"), "")
+		Load(cfg, Variable[int64]("DATABASE_MAX_CONNECTIONS"), 1)
+		Load(cfg, Variable[time.Duration]("DATABASE_TIMEOUT"), time.Second)
+		Load(cfg, Variable[map[string]int]("RATE_LIMITS"), nil)
+
+		assert.Equal(t, 8080, cfg.Int("PORT"))
+		assert.True(t, cfg.Bool("DEBUG"))
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Strings("ALLOWED_ORIGINS"))
+		assert.Equal(t, []int{1, 2, 3}, cfg.Ints("RETRIES"))
+		assert.Equal(t, 0.25, cfg.Float64("RATIO"))
+		assert.Equal(t, "2024-01-02T03:04:05Z", cfg.String("RELEASED"))
+		assert.Equal(t, "2024-06-01", cfg.String("MAINTENANCE_DAY"))
+		assert.Equal(t, "02:30:00", cfg.String("BACKUP_AT"))
+		assert.Equal(t, "postgres://localhost/db", cfg.String("DATABASE_URL"))
+		assert.Equal(t, int64(10), cfg.Int64("DATABASE_MAX_CONNECTIONS"))
+		assert.Equal(t, 5*time.Second, cfg.Duration("DATABASE_TIMEOUT"))
+		assert.Equal(t, map[string]int{"acme": 100, "globex": 250}, cfg.IntMap("RATE_LIMITS"))
+	})
+
+	t.Run("TypeMismatchReportsKey", func(t *testing.T) {
+		doc, err := ParseTOML(strings.NewReader(testTOML), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		err = LoadStrict(cfg, Variable[[]string]("SERVERS"), nil)
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "servers", parseErr.Location)
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+
+		err = LoadStrict(cfg, Variable[time.Duration]("RELEASED"), 0)
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "released", parseErr.Location)
+
+		err = LoadStrict(cfg, Variable[bool]("DATABASE_MAX_CONNECTIONS"), false)
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, `DATABASE_MAX_CONNECTIONS (database.max-connections): cannot parse "10" as bool: mismatched type: expected a boolean, found a number`, err.Error())
+	})
+
+	t.Run("EnvironmentOverrides", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://env/db")
+		doc, err := ParseTOML(strings.NewReader(testTOML), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc, Env)
+		Load(cfg, Variable[string]("DATABASE_URL"), "")
+		Load(cfg, Variable[int]("PORT"), 3000)
+		assert.Equal(t, "postgres://env/db", cfg.String("DATABASE_URL"))
+		assert.Equal(t, 8080, cfg.Int("PORT"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseTOML(strings.NewReader("port = "), nil)
+		assert.Error(t, err)
+		_, err = ParseTOML(strings.NewReader("DATABASE_URL = \"b\"\n[database]\nurl = \"a\"\n"), nil)
+		assert.EqualError(t, err, "DATABASE_URL and database.url both map to DATABASE_URL")
+	})
+}
+
+func TestTOMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte("[server]\nport = true\n"), 0o600))
+
+	doc, err := TOMLFile(path, nil)
+	require.NoError(t, err)
+	v, ok := doc.LookupValue("SERVER_PORT")
+	require.True(t, ok)
+	assert.Equal(t, path+": server.port", v.Location)
+
+	_, err = TOMLFile(filepath.Join(t.TempDir(), "missing.toml"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,158 @@
+package configura
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrUnsupportedType is returned when a value is loaded into a variable whose type is neither built in nor registered
+// with RegisterType. Config.Err reports every such variable that was declared through Load, LoadStrict or
+// LoadRequired, even if it is not set.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// ErrBuiltinType is returned by RegisterType for a type that is built in, whose parsing cannot be replaced.
+var ErrBuiltinType = errors.New("cannot register a built-in type")
+
+// typeDef describes how the values of a type are parsed from their raw form, converted from the structured values of
+// a ValueSource, and formatted back into their raw form.
+type typeDef struct {
+	parse   func(cfg *Config, raw string) (any, error)
+	convert func(cfg *Config, data any) (any, error)
+	format  func(cfg *Config, value any) string
+	builtin bool
+}
+
+var (
+	typesLock sync.RWMutex
+	types     = map[reflect.Type]typeDef{}
+)
+
+// The built-in types. Adding a type only takes a line here.
+func init() {
+	defineScalar(parseString, convertString, formatString)
+	defineScalar(strconv.Atoi, convertNumber[int], strconv.Itoa)
+	defineScalar(bitSized(parseInt[int8], 8), convertNumber[int8], formatSprint[int8])
+	defineScalar(bitSized(parseInt[int16], 16), convertNumber[int16], formatSprint[int16])
+	defineScalar(bitSized(parseInt[int32], 32), convertNumber[int32], formatSprint[int32])
+	defineScalar(parseInt64, convertNumber[int64], formatInt64)
+	defineScalar(bitSized(parseUint[uint], 0), convertNumber[uint], formatSprint[uint])
+	defineScalar(bitSized(parseUint[uint8], 8), convertNumber[uint8], formatSprint[uint8])
+	defineScalar(bitSized(parseUint[uint16], 16), convertNumber[uint16], formatSprint[uint16])
+	defineScalar(bitSized(parseUint[uint32], 32), convertNumber[uint32], formatSprint[uint32])
+	defineScalar(parseUint64, convertNumber[uint64], formatSprint[uint64])
+	defineScalar(bitSized(parseUint[uintptr], strconv.IntSize), convertNumber[uintptr], formatSprint[uintptr])
+	defineScalar(parseBytes, nil, formatBytes)
+	defineScalar(parseRunes, nil, formatRunes)
+	defineScalar(parseFloat32, convertNumber[float32], formatFloat32)
+	defineScalar(parseFloat64, convertNumber[float64], formatFloat64)
+	defineScalar(strconv.ParseBool, convertBool, strconv.FormatBool)
+	defineScalar(time.ParseDuration, nil, time.Duration.String)
+	defineList(parseString, formatString)
+	defineList(strconv.Atoi, strconv.Itoa)
+	defineList(parseFloat64, formatFloat64)
+	defineList(strconv.ParseBool, strconv.FormatBool)
+	defineMap(parseString, formatString)
+	defineMap(strconv.Atoi, strconv.Itoa)
+	defineMap(parseInt64, formatInt64)
+	defineMap(parseFloat64, formatFloat64)
+
+	for t, def := range types {
+		def.builtin = true
+		types[t] = def
+	}
+}
+
+// RegisterType makes variables of type T work with Load, LoadStrict, LoadRequired, FlagSet and every other part of the
+// package that parses or formats values, in the same way as the built-in types. parse converts a raw value, such as
+// the value of an environment variable, into T, and format converts a value of T back into a raw value that parse
+// accepts. Strings, booleans and numbers held by a ValueSource, such as a JSON file, are parsed from their text.
+// Registering a type again replaces its earlier registration. Registrations apply to every configuration in the
+// process, so built-in types cannot be registered, and an error matching ErrBuiltinType is returned for them.
+//
+// Values of any type can be written with Write, read with Get, merged with Merge and checked with Exists, whether or
+// not the type is registered.
+func RegisterType[T any](parse func(raw string) (T, error), format func(value T) string) error {
+	if def, ok := lookupType(reflect.TypeFor[T]()); ok && def.builtin {
+		return fmt.Errorf("%w: %s", ErrBuiltinType, reflect.TypeFor[T]())
+	}
+	defineScalar(parse, convertText[T], format)
+	return nil
+}
+
+// define registers the functions that parse, convert and format the values of T. A nil convert only accepts strings
+// from a ValueSource.
+func define[T any](parse func(cfg *Config, raw string) (T, error), convert func(cfg *Config, data any) (T, error),
+	format func(cfg *Config, value T) string) {
+	def := typeDef{
+		parse: func(cfg *Config, raw string) (any, error) {
+			return parse(cfg, raw)
+		},
+		format: func(cfg *Config, value any) string {
+			return format(cfg, value.(T))
+		},
+	}
+	if convert != nil {
+		def.convert = func(cfg *Config, data any) (any, error) {
+			return convert(cfg, data)
+		}
+	}
+
+	typesLock.Lock()
+	defer typesLock.Unlock()
+	types[reflect.TypeFor[T]()] = def
+}
+
+// defineScalar registers a type whose raw values do not depend on the separators of the configuration.
+func defineScalar[T any](parse func(raw string) (T, error), convert func(cfg *Config, data any) (T, error),
+	format func(value T) string) {
+	define(func(_ *Config, raw string) (T, error) {
+		return parse(raw)
+	}, convert, func(_ *Config, value T) string {
+		return format(value)
+	})
+}
+
+// defineList registers the list type of E, whose raw values are split with the list separator of the configuration.
+func defineList[E any](parse func(raw string) (E, error), format func(value E) string) {
+	define(func(cfg *Config, raw string) ([]E, error) {
+		return parseList(raw, cfg.listSeparator, parse)
+	}, convertList[E], func(cfg *Config, values []E) string {
+		return joinList(formatList(values, format), cfg.listSeparator)
+	})
+}
+
+// defineMap registers the map type of E, whose raw values are split with the map separators of the configuration.
+func defineMap[E any](parse func(raw string) (E, error), format func(value E) string) {
+	define(func(cfg *Config, raw string) (map[string]E, error) {
+		return parseMap(raw, cfg.entrySeparator, cfg.pairSeparator, parse)
+	}, convertMap[E], func(cfg *Config, values map[string]E) string {
+		return joinMap(formatMap(values, format), cfg.entrySeparator, cfg.pairSeparator)
+	})
+}
+
+// lookupType returns the definition of the type t, if it is built in or registered.
+func lookupType(t reflect.Type) (typeDef, bool) {
+	typesLock.RLock()
+	defer typesLock.RUnlock()
+	def, ok := types[t]
+	return def, ok
+}
+
+// bitSized binds the bit size of a parse function for a sized integer type.
+func bitSized[T any](parse func(raw string, bitSize int) (T, error), bitSize int) func(string) (T, error) {
+	return func(raw string) (T, error) {
+		return parse(raw, bitSize)
+	}
+}
+
+// keyName returns the name of a variable of any type.
+func keyName(key any) string {
+	if v := reflect.ValueOf(key); v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(key)
+}
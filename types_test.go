@@ -0,0 +1,103 @@
+package configura
+
+import (
+	"errors"
+	"flag"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseIP(raw string) (net.IP, error) {
+	ip := net.ParseIP(raw)
+	if ip == nil {
+		return nil, errors.New("invalid IP address")
+	}
+	return ip, nil
+}
+
+func TestRegisterType(t *testing.T) {
+	require.NoError(t, RegisterType(parseIP, net.IP.String))
+	ipKey := Variable[net.IP]("TYPES_BIND_IP")
+
+	t.Run("Load", func(t *testing.T) {
+		cfg := New(MapSource{string(ipKey): "10.0.0.1"})
+		Load(cfg, ipKey, net.IPv4(127, 0, 0, 1))
+		assert.Equal(t, net.ParseIP("10.0.0.1"), Get(cfg, ipKey))
+		assert.NoError(t, cfg.Exists(ipKey))
+
+		cfg = New(MapSource{string(ipKey): "localhost"})
+		err := LoadStrict(cfg, ipKey, net.IPv4(127, 0, 0, 1))
+		assert.ErrorContains(t, err, "invalid IP address")
+		assert.Equal(t, net.IPv4(127, 0, 0, 1), Get(cfg, ipKey))
+	})
+
+	t.Run("Document", func(t *testing.T) {
+		doc, err := ParseJSON(strings.NewReader(`{"types": {"bind_ip": "::1"}, "other": 1}`), nil)
+		require.NoError(t, err)
+		cfg := New(doc)
+		require.NoError(t, LoadRequired(cfg, ipKey))
+		assert.Equal(t, net.IPv6loopback, Get(cfg, ipKey))
+
+		err = LoadStrict(cfg, Variable[net.IP]("TYPES"), nil)
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+	})
+
+	t.Run("WriteAndMerge", func(t *testing.T) {
+		cfg := New(MapSource{})
+		require.NoError(t, Write(cfg, map[Variable[net.IP]]net.IP{ipKey: net.IPv4(10, 0, 0, 2)}))
+		merged := Merge(New(MapSource{}), cfg)
+		assert.Equal(t, net.IPv4(10, 0, 0, 2), Get(merged, ipKey))
+		assert.NoError(t, merged.Exists(ipKey))
+		assert.ErrorIs(t, merged.Exists(Variable[net.IP]("TYPES_OTHER_IP")), ErrMissingVariable)
+	})
+
+	t.Run("Flags", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Load(cfg, ipKey, net.IPv4(127, 0, 0, 1))
+		fs := cfg.FlagSet("test", flag.ContinueOnError)
+		assert.Equal(t, "127.0.0.1", fs.Lookup("types-bind-ip").DefValue)
+		require.NoError(t, fs.Parse([]string{"--types-bind-ip=10.0.0.3"}))
+		assert.Equal(t, net.ParseIP("10.0.0.3"), Get(cfg, ipKey))
+	})
+
+	t.Run("BuiltinType", func(t *testing.T) {
+		err := RegisterType(func(string) (int, error) { return 42, nil }, strconv.Itoa)
+		assert.ErrorIs(t, err, ErrBuiltinType)
+		assert.EqualError(t, err, "cannot register a built-in type: int")
+
+		cfg := New(MapSource{"TYPES_PORT": "8080"})
+		Load(cfg, Variable[int]("TYPES_PORT"), 0)
+		assert.Equal(t, 8080, cfg.Int("TYPES_PORT"), "The built-in parser should be kept")
+	})
+}
+
+func TestUnsupportedType(t *testing.T) {
+	type point struct{ X, Y int }
+	key := Variable[point]("TYPES_POINT")
+
+	cfg := New(MapSource{string(key): "1,2"})
+	err := LoadStrict(cfg, key, point{X: 1})
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+	assert.Equal(t, point{X: 1}, Get(cfg, key), "The fallback should be registered")
+
+	require.NoError(t, Write(cfg, map[Variable[point]]point{key: {X: 3, Y: 4}}))
+	assert.Equal(t, point{X: 3, Y: 4}, Get(cfg, key), "Values of any type can be written")
+
+	t.Run("Load", func(t *testing.T) {
+		cfg := New(MapSource{string(key): "1,2"})
+		Load(cfg, key, point{})
+		err := cfg.Err()
+		require.ErrorIs(t, err, ErrUnsupportedType, "A value that is set should not be ignored silently")
+		assert.ErrorContains(t, err, "unsupported type: TYPES_POINT (")
+		assert.NotErrorIs(t, err, ErrInvalidVariable, "The type should be reported once")
+
+		cfg = New(MapSource{})
+		Load(cfg, key, point{})
+		assert.ErrorIs(t, cfg.Err(), ErrUnsupportedType, "The type should be reported even if the value is not set")
+	})
+}
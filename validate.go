@@ -0,0 +1,238 @@
+package configura
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrValidation is matched by a ValidationError.
+var ErrValidation = errors.New("configuration variables failed validation")
+
+// Rule checks a value of a variable, and returns an error describing why the value is not valid, such as "must be at
+// least 1". Any func(T) error can be used as a Rule.
+type Rule[T any] func(value T) error
+
+// Range requires a value to be between min and max, inclusive.
+func Range[T cmp.Ordered](min, max T) Rule[T] {
+	return func(value T) error {
+		if value < min || value > max {
+			return fmt.Errorf("must be between %v and %v", min, max)
+		}
+		return nil
+	}
+}
+
+// Min requires a value to be at least min.
+func Min[T cmp.Ordered](min T) Rule[T] {
+	return func(value T) error {
+		if value < min {
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	}
+}
+
+// Max requires a value to be at most max.
+func Max[T cmp.Ordered](max T) Rule[T] {
+	return func(value T) error {
+		if value > max {
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
+
+// OneOf requires a value to be equal to one of values.
+func OneOf[T comparable](values ...T) Rule[T] {
+	return func(value T) error {
+		if !slices.Contains(values, value) {
+			items := make([]string, len(values))
+			for i, v := range values {
+				items[i] = fmt.Sprint(v)
+			}
+			return fmt.Errorf("must be one of %s", strings.Join(items, ", "))
+		}
+		return nil
+	}
+}
+
+// Regexp requires a value to match the regular expression pattern. It panics if pattern cannot be compiled, like
+// regexp.MustCompile.
+func Regexp(pattern string) Rule[string] {
+	re := regexp.MustCompile(pattern)
+	return func(value string) error {
+		if !re.MatchString(value) {
+			return fmt.Errorf("must match %s", re)
+		}
+		return nil
+	}
+}
+
+// NonEmpty requires a string, slice or map to have at least one element, and a value of any other type not to be its
+// zero value.
+func NonEmpty[T any]() Rule[T] {
+	return func(value T) error {
+		v := reflect.ValueOf(&value).Elem()
+		switch v.Kind() {
+		case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+			if v.Len() > 0 {
+				return nil
+			}
+		default:
+			if !v.IsZero() {
+				return nil
+			}
+		}
+		return errors.New("must not be empty")
+	}
+}
+
+// URL requires a value to be an absolute URL, with a scheme and a host. If schemes are given, the scheme must be one
+// of them.
+func URL(schemes ...string) Rule[string] {
+	return func(value string) error {
+		u, err := url.Parse(value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.New("must be an absolute URL")
+		}
+		if len(schemes) > 0 && !slices.Contains(schemes, u.Scheme) {
+			return fmt.Errorf("must be a URL with scheme %s", strings.Join(schemes, ", "))
+		}
+		return nil
+	}
+}
+
+// Validate attaches rules to key, in addition to any rules it already has. The rules are evaluated whenever the value
+// of key changes: a value that is loaded or reloaded and violates a rule is still registered, but the violation is
+// reported by Config.Err, LoadStrict and LoadRequired, while Write refuses the value altogether. The current value of
+// key, if any, is evaluated right away.
+func Validate[T any](cfg *Config, key Variable[T], rules ...Rule[T]) {
+	defer cfg.unlock(cfg.lock())
+	if v, ok := cfg.rules[key].(*ruleSet[T]); ok {
+		rules = append(slices.Clone(v.rules), rules...)
+	}
+	cfg.rules[key] = &ruleSet[T]{key: key, rules: rules}
+	if value, ok := cfg.value(key); ok {
+		cfg.check(key, value)
+	}
+}
+
+// validator evaluates the rules of a variable.
+type validator interface {
+	// validate returns a Violation for every rule that value violates.
+	validate(value any) []Violation
+}
+
+// ruleSet holds the rules of a Variable[T].
+type ruleSet[T any] struct {
+	key   Variable[T]
+	rules []Rule[T]
+}
+
+func (s *ruleSet[T]) validate(value any) []Violation {
+	v, _ := value.(T)
+	var violations []Violation
+	for _, rule := range s.rules {
+		if err := rule(v); err != nil {
+			violations = append(violations, Violation{Key: string(s.key), Type: typeName(s.key), Err: err})
+		}
+	}
+	return violations
+}
+
+// check evaluates the rules of key against value, and records the violations. The caller must hold the write lock.
+func (c *Config) check(key, value any) {
+	if v, ok := c.rules[key]; ok {
+		if violations := v.validate(value); len(violations) > 0 {
+			c.violations[key] = c.redactViolations(key, violations)
+			return
+		}
+	}
+	delete(c.violations, key)
+}
+
+// validate returns the violations of the rules of the variables among values, without recording them. The caller must
+// hold the lock.
+func validate[T any](cfg *Config, values map[Variable[T]]T) error {
+	var violations []Violation
+	for key, value := range values {
+		if v, ok := cfg.rules[key]; ok {
+			violations = append(violations, cfg.redactViolations(key, v.validate(value))...)
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return newValidationError(violations)
+}
+
+// violated returns the recorded violations of the rules of keys, or nil if there are none. The caller must hold the
+// lock.
+func (c *Config) violated(keys ...any) error {
+	var violations []Violation
+	for _, key := range keys {
+		violations = append(violations, c.violations[key]...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return newValidationError(violations)
+}
+
+// Violation describes a value that violates a rule of its variable.
+type Violation struct {
+	Key  string
+	Type string
+	Err  error
+}
+
+// Error implements the error interface for Violation.
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %v", v.Key, v.Err)
+}
+
+// Unwrap returns the error of the rule.
+func (v Violation) Unwrap() error {
+	return v.Err
+}
+
+// ValidationError aggregates every violation of the rules attached with Validate.
+type ValidationError struct {
+	Violations []Violation
+}
+
+// newValidationError returns a ValidationError listing violations in order of name and type.
+func newValidationError(violations []Violation) ValidationError {
+	violations = slices.Clone(violations)
+	slices.SortStableFunc(violations, func(a, b Violation) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Type, b.Type))
+	})
+	return ValidationError{Violations: violations}
+}
+
+// Error implements the error interface for ValidationError.
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Error()
+	}
+	return "configuration variables failed validation: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap allows the error to be matched against ErrValidation, and against each individual Violation.
+func (e ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations)+1)
+	errs = append(errs, ErrValidation)
+	for _, v := range e.Violations {
+		errs = append(errs, v)
+	}
+	return errs
+}
+
+var _ error = (*ValidationError)(nil)
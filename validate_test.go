@@ -0,0 +1,128 @@
+package configura
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	assert.NoError(t, Range(1, 65535)(80))
+	assert.EqualError(t, Range(1, 65535)(70000), "must be between 1 and 65535")
+	assert.NoError(t, Min(time.Second)(time.Minute))
+	assert.EqualError(t, Min(time.Second)(time.Millisecond), "must be at least 1s")
+	assert.EqualError(t, Max(1.5)(2), "must be at most 1.5")
+	assert.NoError(t, OneOf("debug", "info")("info"))
+	assert.EqualError(t, OneOf("debug", "info")("trace"), "must be one of debug, info")
+	assert.NoError(t, Regexp(`^[a-z]+$`)("abc"))
+	assert.EqualError(t, Regexp(`^[a-z]+$`)("ABC"), "must match ^[a-z]+$")
+	assert.Panics(t, func() { Regexp(`(`) })
+	assert.NoError(t, NonEmpty[string]()("a"))
+	assert.EqualError(t, NonEmpty[string]()(""), "must not be empty")
+	assert.EqualError(t, NonEmpty[[]string]()([]string{}), "must not be empty")
+	assert.EqualError(t, NonEmpty[map[string]int]()(nil), "must not be empty")
+	assert.EqualError(t, NonEmpty[int]()(0), "must not be empty")
+	assert.NoError(t, URL()("https://example.com/path"))
+	assert.EqualError(t, URL()("example.com"), "must be an absolute URL")
+	assert.EqualError(t, URL("https")("http://example.com"), "must be a URL with scheme https")
+}
+
+func TestValidate(t *testing.T) {
+	portKey := Variable[int]("VALIDATE_PORT")
+	levelKey := Variable[string]("VALIDATE_LEVEL")
+
+	t.Run("Load", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "70000", string(levelKey): "trace"})
+		Validate(cfg, portKey, Range(1, 65535))
+		Validate(cfg, levelKey, OneOf("debug", "info", "warn"), NonEmpty[string]())
+		Load(cfg, portKey, 8080)
+		Load(cfg, levelKey, "info")
+
+		assert.Equal(t, 70000, cfg.Int(portKey), "Loaded values should be registered even if they are not valid")
+		err := cfg.Err()
+		require.ErrorIs(t, err, ErrValidation)
+		var validationErr ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []Violation{
+			{Key: "VALIDATE_LEVEL", Type: "string", Err: validationErr.Violations[0].Err},
+			{Key: "VALIDATE_PORT", Type: "int", Err: validationErr.Violations[1].Err},
+		}, validationErr.Violations)
+		assert.EqualError(t, err, "configuration variables failed validation: VALIDATE_LEVEL: must be one of debug, info, warn; VALIDATE_PORT: must be between 1 and 65535")
+	})
+
+	t.Run("LoadStrict", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "0"})
+		Validate(cfg, portKey, Min(1))
+		err := LoadStrict(cfg, portKey, 8080)
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.ErrorContains(t, err, "VALIDATE_PORT: must be at least 1")
+
+		cfg = New(MapSource{})
+		Validate(cfg, levelKey, NonEmpty[string]())
+		assert.ErrorIs(t, LoadRequired(cfg, levelKey, MapSource{string(levelKey): ""}), ErrValidation)
+	})
+
+	t.Run("ValidateAfterLoad", func(t *testing.T) {
+		cfg := New(MapSource{string(portKey): "70000"})
+		Load(cfg, portKey, 8080)
+		require.NoError(t, cfg.Err())
+		Validate(cfg, portKey, Max(65535))
+		assert.ErrorIs(t, cfg.Err(), ErrValidation, "The current value should be evaluated right away")
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Validate(cfg, portKey, Range(1, 65535))
+		Load(cfg, portKey, 8080)
+
+		err := Write(cfg, map[Variable[int]]int{portKey: 0})
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.Equal(t, 8080, cfg.Int(portKey), "Values that violate a rule should not be written")
+		assert.NoError(t, cfg.Err())
+
+		require.NoError(t, Write(cfg, map[Variable[int]]int{portKey: 9090}))
+		assert.Equal(t, 9090, cfg.Int(portKey))
+	})
+
+	t.Run("ViolationsClearWhenFixed", func(t *testing.T) {
+		src := MapSource{string(portKey): "70000"}
+		cfg := New(src)
+		Validate(cfg, portKey, Range(1, 65535))
+		Load(cfg, portKey, 8080)
+		require.Error(t, cfg.Err())
+
+		src[string(portKey)] = "443"
+		assert.NoError(t, cfg.Refresh())
+	})
+
+	t.Run("CustomRule", func(t *testing.T) {
+		even := errors.New("must be even")
+		cfg := New(MapSource{string(portKey): "81"})
+		Validate(cfg, portKey, func(v int) error {
+			if v%2 != 0 {
+				return even
+			}
+			return nil
+		})
+		Load(cfg, portKey, 80)
+		assert.ErrorIs(t, cfg.Err(), even)
+	})
+
+	t.Run("Merge", func(t *testing.T) {
+		cfg := New(MapSource{})
+		Validate(cfg, portKey, Range(1, 65535))
+		Load(cfg, portKey, 8080)
+		other := New(MapSource{})
+		Write(other, map[Variable[int]]int{portKey: 70000})
+
+		merged := Merge(cfg, other)
+		assert.ErrorIs(t, merged.Err(), ErrValidation, "Merged values should be evaluated against the merged rules")
+		assert.ErrorIs(t, Write(merged, map[Variable[int]]int{portKey: 0}), ErrValidation)
+
+		Validate(merged, portKey, Max(1000))
+		assert.NoError(t, Write(cfg, map[Variable[int]]int{portKey: 2000}), "Rules added to a merged configuration should not affect the others")
+	})
+}
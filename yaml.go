@@ -0,0 +1,161 @@
+package configura
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLFile reads the YAML document at path with ParseYAML. The locations of its values are prefixed with path.
+func YAMLFile(path string, mapper KeyMapper) (*Document, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	root, lines, err := decodeYAML(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return newDocument(root, mapper, lines.location, path)
+}
+
+// ParseYAML parses a YAML document whose top level is a mapping into a Document. Nested mappings are flattened into
+// variable names with mapper, which defaults to UpperSnakeCase when nil. Anchors, aliases and << merge keys are
+// resolved, null values are treated as if they were absent, and timestamps are kept in their RFC 3339 form. The
+// location of each value is the line of its key, such as "line 12".
+func ParseYAML(r io.Reader, mapper KeyMapper) (*Document, error) {
+	root, lines, err := decodeYAML(r)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(root, mapper, lines.location, "")
+}
+
+// yamlLines maps the path of every value in a YAML document to the line of its key.
+type yamlLines map[string]int
+
+func (l yamlLines) location(path []string) string {
+	return fmt.Sprintf("line %d", l[strings.Join(path, "\x00")])
+}
+
+// decodeYAML decodes a single YAML document from r, along with the lines of its keys.
+func decodeYAML(r io.Reader) (map[string]any, yamlLines, error) {
+	dec := yaml.NewDecoder(r)
+
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return map[string]any{}, yamlLines{}, nil
+		}
+		return nil, nil, err
+	}
+	if err := dec.Decode(new(yaml.Node)); !errors.Is(err, io.EOF) {
+		return nil, nil, errors.New("expected a single YAML document")
+	}
+
+	if len(doc.Content) == 0 {
+		return map[string]any{}, yamlLines{}, nil
+	}
+
+	lines := yamlLines{}
+	root, err := yamlValue(doc.Content[0], nil, lines)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	object, ok := root.(map[string]any)
+	if !ok {
+		if root == nil {
+			return map[string]any{}, lines, nil
+		}
+		return nil, nil, fmt.Errorf("expected a mapping at the top level, found %s", kindOf(root))
+	}
+	return object, lines, nil
+}
+
+// yamlValue converts node into a string, bool, number, []any or map[string]any, and records the line of every key
+// below path in lines.
+func yamlValue(node *yaml.Node, path []string, lines yamlLines) (any, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return yamlValue(node.Alias, path, lines)
+	case yaml.ScalarNode:
+		var v any
+		if err := node.Decode(&v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		if t, ok := v.(time.Time); ok {
+			return t.Format(time.RFC3339Nano), nil
+		}
+		return v, nil
+	case yaml.SequenceNode:
+		items := make([]any, len(node.Content))
+		for i, item := range node.Content {
+			// Values within a sequence are not flattened, so the lines of their keys are of no use.
+			v, err := yamlValue(item, path, yamlLines{})
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return items, nil
+	case yaml.MappingNode:
+		object := make(map[string]any)
+		if err := yamlMapping(node, path, lines, object); err != nil {
+			return nil, err
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("line %d: unsupported YAML node", node.Line)
+}
+
+// yamlMapping adds the entries of the mapping node to object. The entries of mappings merged in with a << key are added
+// first, so that the keys of the mapping itself take precedence.
+func yamlMapping(node *yaml.Node, path []string, lines yamlLines, object map[string]any) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Tag != "!!merge" {
+			continue
+		}
+
+		merged := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			merged = value.Content
+		}
+		for _, m := range merged {
+			for m.Kind == yaml.AliasNode {
+				m = m.Alias
+			}
+			if m.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d: a merge key must refer to a mapping", m.Line)
+			}
+			if err := yamlMapping(m, path, lines, object); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Tag == "!!merge" {
+			continue
+		}
+
+		p := append(path[:len(path):len(path)], key.Value)
+		lines[strings.Join(p, "\x00")] = key.Line
+		v, err := yamlValue(value, p, lines)
+		if err != nil {
+			return err
+		}
+		object[key.Value] = v
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package configura
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testYAML = `# Service configuration
+port: 8080
+debug: true
+defaults: &defaults
+  timeout: 5s
+  max-connections: 10
+database:
+  <<: *defaults
+  url: postgres://localhost/db
+  max-connections: 20
+replica:
+  <<: *defaults
+allowed_origins:
+  - https://a.example.com
+  - https://b.example.com
+rate_limits: {acme: 100, globex: 250}
+ratio: 0.25
+released: 2024-01-02T03:04:05Z
+shared_origins: &origins [x, y]
+mirrored_origins: *origins
+unset: ~
+`
+
+func TestParseYAML(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		doc, err := ParseYAML(strings.NewReader(testYAML), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		Load(cfg, Variable[int]("PORT"), 3000)
+		Load(cfg, Variable[bool]("DEBUG"), false)
+		Load(cfg, Variable[string]("DATABASE_URL"), "")
+		Load(cfg, Variable[int]("DATABASE_MAX_CONNECTIONS"), 1)
+		Load(cfg, Variable[time.Duration]("DATABASE_TIMEOUT"), time.Second)
+		Load(cfg, Variable[int]("REPLICA_MAX_CONNECTIONS"), 1)
+		Load(cfg, Variable[time.Duration]("REPLICA_TIMEOUT"), time.Second)
+		Load(cfg, Variable[[]string]("ALLOWED_ORIGINS"), nil)
+		Load(cfg, Variable[[]string]("MIRRORED_ORIGINS"), nil)
+		Load(cfg, Variable[map[string]int]("RATE_LIMITS"), nil)
+		Load(cfg, Variable[float32]("RATIO"), 1)
+		Load(cfg, Variable[string]("RELEASED"), "")
+		Load(cfg, Variable[string]("UNSET"), "fallback")
+
+		assert.Equal(t, 8080, cfg.Int("PORT"))
+		assert.True(t, cfg.Bool("DEBUG"))
+		assert.Equal(t, "postgres://localhost/db", cfg.String("DATABASE_URL"))
+		assert.Equal(t, 20, cfg.Int("DATABASE_MAX_CONNECTIONS"), "Keys of the mapping should override merged keys")
+		assert.Equal(t, 5*time.Second, cfg.Duration("DATABASE_TIMEOUT"))
+		assert.Equal(t, 10, cfg.Int("REPLICA_MAX_CONNECTIONS"))
+		assert.Equal(t, 5*time.Second, cfg.Duration("REPLICA_TIMEOUT"))
+		assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Strings("ALLOWED_ORIGINS"))
+		assert.Equal(t, []string{"x", "y"}, cfg.Strings("MIRRORED_ORIGINS"))
+		assert.Equal(t, map[string]int{"acme": 100, "globex": 250}, cfg.IntMap("RATE_LIMITS"))
+		assert.Equal(t, float32(0.25), cfg.Float32("RATIO"))
+		assert.Equal(t, "2024-01-02T03:04:05Z", cfg.String("RELEASED"))
+		assert.Equal(t, "fallback", cfg.String("UNSET"))
+	})
+
+	t.Run("TypeMismatchReportsLine", func(t *testing.T) {
+		doc, err := ParseYAML(strings.NewReader(testYAML), nil)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		err = LoadStrict(cfg, Variable[int]("DEBUG"), 0)
+		var parseErr ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "line 3", parseErr.Location)
+		assert.ErrorIs(t, err, ErrTypeMismatch)
+		assert.Equal(t, `DEBUG (line 3): cannot parse "true" as int: mismatched type: expected a number, found a boolean`, err.Error())
+
+		err = LoadStrict(cfg, Variable[bool]("DATABASE_MAX_CONNECTIONS"), false)
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "line 10", parseErr.Location)
+
+		err = LoadStrict(cfg, Variable[[]int]("ALLOWED_ORIGINS"), nil)
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "line 13", parseErr.Location)
+	})
+
+	t.Run("DotPath", func(t *testing.T) {
+		doc, err := ParseYAML(strings.NewReader(testYAML), DotPath)
+		require.NoError(t, err)
+
+		cfg := New(doc)
+		Load(cfg, Variable[string]("database.url"), "")
+		assert.Equal(t, "postgres://localhost/db", cfg.String("database.url"))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		doc, err := ParseYAML(strings.NewReader(""), nil)
+		require.NoError(t, err)
+		_, ok := doc.Lookup("PORT")
+		assert.False(t, ok)
+
+		doc, err = ParseYAML(strings.NewReader("# only a comment\n"), nil)
+		require.NoError(t, err)
+		_, ok = doc.Lookup("PORT")
+		assert.False(t, ok)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseYAML(strings.NewReader("a: [1, 2"), nil)
+		assert.Error(t, err)
+		_, err = ParseYAML(strings.NewReader("- 1\n- 2"), nil)
+		assert.EqualError(t, err, "expected a mapping at the top level, found an array")
+		_, err = ParseYAML(strings.NewReader("a: 1\n---\nb: 2"), nil)
+		assert.EqualError(t, err, "expected a single YAML document")
+		_, err = ParseYAML(strings.NewReader("a: &a 1\nb:\n  <<: *a"), nil)
+		assert.EqualError(t, err, "line 1: a merge key must refer to a mapping")
+		_, err = ParseYAML(strings.NewReader("database:\n  url: a\nDATABASE_URL: b\n"), nil)
+		assert.EqualError(t, err, "line 3 and line 2 both map to DATABASE_URL")
+	})
+}
+
+func TestYAMLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  port: true\n"), 0o600))
+
+	doc, err := YAMLFile(path, nil)
+	require.NoError(t, err)
+	v, ok := doc.LookupValue("SERVER_PORT")
+	require.True(t, ok)
+	assert.Equal(t, path+": line 2", v.Location)
+
+	_, err = YAMLFile(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}